@@ -0,0 +1,91 @@
+// Package auth_interceptor - общая для сервисов аутентификация gRPC-вызовов:
+// серверные интерсепторы достают bearer-токен из метаданных, проверяют его
+// и кладут пользователя в контекст запроса.
+package auth_interceptor
+
+import (
+	"context"
+
+	"github.com/dgrijalva/jwt-go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	models "fin-trans/models_package"
+)
+
+// Identity - аутентифицированный вызывающий
+type Identity struct {
+	UserID    int32
+	Username  string
+	SessionID string
+	Role      string
+	// Service - вызов от внутреннего сервиса, а не от пользователя
+	Service bool
+	// ServiceAccountID и Scopes заполнены для вызовов по API-ключу сервисного аккаунта
+	ServiceAccountID int32
+	Scopes           []string
+}
+
+// Claims - содержимое access-токена, выдаваемого AuthService
+type Claims struct {
+	UserID    int32  `json:"user_id"`
+	Username  string `json:"username"`
+	SessionID string `json:"sid"`
+	Role      string `json:"role"`
+	jwt.StandardClaims
+}
+
+type identityKey struct{}
+
+func NewContext(ctx context.Context, id *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, id)
+}
+
+// FromContext возвращает вызывающего, которого положил интерсептор
+func FromContext(ctx context.Context) (*Identity, bool) {
+	id, ok := ctx.Value(identityKey{}).(*Identity)
+	return id, ok
+}
+
+// IsServiceAccount - вызов по API-ключу сервисного аккаунта
+func (id *Identity) IsServiceAccount() bool {
+	return id.ServiceAccountID != 0
+}
+
+// HasScope - есть ли у ключа сервисного аккаунта scope
+func (id *Identity) HasScope(scope string) bool {
+	for _, s := range id.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// CanAccessUser - может ли вызывающий действовать от имени пользователя userID
+// (например, отправлять переводы с его карт). Сервисный аккаунт уже прошёл
+// проверку scope метода и действует за любого пользователя.
+func (id *Identity) CanAccessUser(userID int32) bool {
+	return id.Service || id.IsServiceAccount() || id.UserID == userID
+}
+
+// CanViewUser - может ли вызывающий видеть данные пользователя userID.
+// Операторы и администраторы видят данные всех клиентов.
+func (id *Identity) CanViewUser(userID int32) bool {
+	return id.CanAccessUser(userID) || id.HasRole(models.RoleOperator)
+}
+
+// HasRole - роль вызывающего не ниже role. Неизвестная роль не даёт прав.
+func (id *Identity) HasRole(role string) bool {
+	have, ok := roleRank[id.Role]
+	return ok && have >= roleRank[role]
+}
+
+// Require возвращает вызывающего или ошибку Unauthenticated, если интерсептор его не положил
+func Require(ctx context.Context) (*Identity, error) {
+	id, ok := FromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+	return id, nil
+}
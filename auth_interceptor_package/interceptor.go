@@ -0,0 +1,144 @@
+package auth_interceptor
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// ServiceTokenEnv - переменная окружения с общим токеном внутренних сервисов
+const ServiceTokenEnv = "FINTRANS_SERVICE_TOKEN"
+
+type options struct {
+	serviceToken string
+}
+
+type Option func(*options)
+
+// WithServiceToken принимает общий токен внутренних сервисов (например, обработчика очереди переводов).
+// Пустой токен не принимается никогда.
+func WithServiceToken(token string) Option {
+	return func(o *options) {
+		o.serviceToken = token
+	}
+}
+
+// UnaryServerInterceptor аутентифицирует вызов и проверяет его по таблице policy
+func UnaryServerInterceptor(v Validator, policy Policy, opts ...Option) grpc.UnaryServerInterceptor {
+	o := newOptions(opts)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := o.authorize(ctx, v, policy, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func StreamServerInterceptor(v Validator, policy Policy, opts ...Option) grpc.StreamServerInterceptor {
+	o := newOptions(opts)
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := o.authorize(ss.Context(), v, policy, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// authorize находит правило метода, аутентифицирует вызывающего и проверяет его роль
+func (o *options) authorize(ctx context.Context, v Validator, policy Policy, fullMethod string) (context.Context, error) {
+	rule, ok := policy.lookup(fullMethod)
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, "method has no access policy")
+	}
+	if rule.Public {
+		return ctx, nil
+	}
+
+	ctx, err := o.authenticate(ctx, v)
+	if err != nil {
+		return nil, err
+	}
+	id, _ := FromContext(ctx)
+	if err := rule.authorize(id); err != nil {
+		return nil, err
+	}
+	return ctx, nil
+}
+
+func (o *options) authenticate(ctx context.Context, v Validator) (context.Context, error) {
+	token, err := TokenFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if o.serviceToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(o.serviceToken)) == 1 {
+		return NewContext(ctx, &Identity{Username: "service", Service: true}), nil
+	}
+
+	id, err := v.Validate(ctx, token)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired token")
+	}
+	return NewContext(ctx, id), nil
+}
+
+// TokenFromContext достаёт bearer-токен из входящих метаданных gRPC.
+// grpc-gateway пробрасывает HTTP-заголовок Authorization под тем же именем.
+func TokenFromContext(ctx context.Context) (string, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		values = md.Get("grpcgateway-authorization")
+	}
+	if len(values) == 0 {
+		return "", status.Error(codes.Unauthenticated, "authorization token is required")
+	}
+
+	scheme, token, found := strings.Cut(values[0], " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", status.Error(codes.Unauthenticated, "authorization header must be 'Bearer <token>'")
+	}
+	return token, nil
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+// bearerToken передаёт токен в метаданных каждого исходящего вызова
+type bearerToken string
+
+// BearerToken - учётные данные клиента для grpc.WithPerRPCCredentials
+func BearerToken(token string) credentials.PerRPCCredentials {
+	return bearerToken(token)
+}
+
+func (t bearerToken) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+// Сервисы общаются без TLS, как и остальные gRPC-соединения проекта
+func (t bearerToken) RequireTransportSecurity() bool {
+	return false
+}
@@ -0,0 +1,161 @@
+package auth_interceptor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	models "fin-trans/models_package"
+)
+
+// roleRank - старшинство ролей, старшая роль может всё, что младшая
+var roleRank = map[string]int{
+	models.RoleCustomer: 1,
+	models.RoleOperator: 2,
+	models.RoleAdmin:    3,
+}
+
+// ValidRole - известна ли роль
+func ValidRole(role string) bool {
+	_, ok := roleRank[role]
+	return ok
+}
+
+// Scopes API-ключей сервисных аккаунтов. Каждый метод, открытый сервисным
+// аккаунтам, требует ровно один scope.
+const (
+	ScopeCardsRead          = "cards:read"
+	ScopeCardsWrite         = "cards:write"
+	ScopeTransactionsRead   = "transactions:read"
+	ScopeTransactionsCreate = "transactions:create"
+	ScopeFundsWrite         = "funds:write" // пополнение и списание средств внешними системами
+)
+
+var knownScopes = map[string]bool{
+	ScopeCardsRead:          true,
+	ScopeCardsWrite:         true,
+	ScopeTransactionsRead:   true,
+	ScopeTransactionsCreate: true,
+	ScopeFundsWrite:         true,
+}
+
+// ValidScope - известен ли scope
+func ValidScope(scope string) bool {
+	return knownScopes[scope]
+}
+
+// Rule - кто может вызывать метод
+type Rule struct {
+	Public  bool   // без аутентификации
+	MinRole string // минимальная роль пользователя, пустая - пользователям метод недоступен
+	Service bool   // доступен внутренним сервисам по service-токену
+	Scope   string // scope, открывающий метод сервисным аккаунтам; пустой - им метод недоступен
+}
+
+// Public - метод доступен без токена
+var Public = Rule{Public: true}
+
+// Allow - метод доступен пользователям с ролью не ниже minRole
+func Allow(minRole string) Rule {
+	return Rule{MinRole: minRole}
+}
+
+// ServiceOnly - метод доступен только внутренним сервисам
+func ServiceOnly() Rule {
+	return Rule{Service: true}
+}
+
+// ScopeOnly - метод доступен только сервисным аккаунтам с ключом, у которого есть scope.
+// Для операций внешних систем, которые пользователи, даже операторы, делать не должны.
+func ScopeOnly(scope string) Rule {
+	return Rule{Scope: scope}
+}
+
+// OrService дополнительно открывает метод внутренним сервисам
+func (r Rule) OrService() Rule {
+	r.Service = true
+	return r
+}
+
+// OrScope дополнительно открывает метод сервисным аккаунтам с ключом, у которого есть scope
+func (r Rule) OrScope(scope string) Rule {
+	r.Scope = scope
+	return r
+}
+
+// Policy - правила доступа по полному имени метода ("/pkg.Service/Method").
+// Ключ, оканчивающийся на "/", задаёт правило для всех методов сервиса.
+// Метод без правила запрещён всем, поэтому каждый новый RPC обязан его объявить.
+type Policy map[string]Rule
+
+// ReflectionPolicy открывает gRPC reflection
+var ReflectionPolicy = Policy{
+	"/grpc.reflection.v1.ServerReflection/":      Public,
+	"/grpc.reflection.v1alpha.ServerReflection/": Public,
+}
+
+// Merge возвращает новую таблицу с правилами p и others
+func (p Policy) Merge(others ...Policy) Policy {
+	merged := make(Policy, len(p))
+	for method, rule := range p {
+		merged[method] = rule
+	}
+	for _, other := range others {
+		for method, rule := range other {
+			merged[method] = rule
+		}
+	}
+	return merged
+}
+
+func (p Policy) lookup(fullMethod string) (Rule, bool) {
+	if rule, ok := p[fullMethod]; ok {
+		return rule, true
+	}
+	for prefix, rule := range p {
+		if strings.HasSuffix(prefix, "/") && strings.HasPrefix(fullMethod, prefix) {
+			return rule, true
+		}
+	}
+	return Rule{}, false
+}
+
+// Check проверяет, что у каждого зарегистрированного на сервере метода есть правило.
+// Вызывается при старте сервиса после регистрации всех сервисов.
+func (p Policy) Check(s *grpc.Server) error {
+	var missing []string
+	for service, info := range s.GetServiceInfo() {
+		for _, method := range info.Methods {
+			fullMethod := "/" + service + "/" + method.Name
+			if _, ok := p.lookup(fullMethod); !ok {
+				missing = append(missing, fullMethod)
+			}
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("нет правил доступа для методов: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// authorize проверяет, что вызывающий подходит под правило метода
+func (r Rule) authorize(id *Identity) error {
+	switch {
+	case id.Service:
+		if r.Service {
+			return nil
+		}
+	case id.IsServiceAccount():
+		if r.Scope != "" && id.HasScope(r.Scope) {
+			return nil
+		}
+	case r.MinRole != "" && id.HasRole(r.MinRole):
+		return nil
+	}
+	return status.Error(codes.PermissionDenied, "insufficient permissions")
+}
@@ -0,0 +1,148 @@
+package auth_interceptor
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+
+	"fin-trans/auth_service/jwks"
+	authpb "fin-trans/auth_service/proto"
+)
+
+var ErrInvalidToken = errors.New("недействительный токен")
+
+// APIKeyPrefix отличает API-ключи сервисных аккаунтов от JWT пользователей
+const APIKeyPrefix = "ftk_"
+
+// IsAPIKey - токен является API-ключом сервисного аккаунта
+func IsAPIKey(token string) bool {
+	return strings.HasPrefix(token, APIKeyPrefix)
+}
+
+// Validator проверяет bearer-токен и возвращает вызывающего
+type Validator interface {
+	Validate(ctx context.Context, token string) (*Identity, error)
+}
+
+// ValidatorFromEnv выбирает способ проверки по AUTH_TOKEN_VALIDATION:
+// "jwks" (по умолчанию) - локально по публичным ключам, "remote" - через ValidateToken
+func ValidatorFromEnv(client authpb.AuthServiceClient) Validator {
+	if os.Getenv("AUTH_TOKEN_VALIDATION") == "remote" {
+		return NewRemoteValidator(client)
+	}
+	return NewJWKSValidator(client)
+}
+
+// Как часто перечитывать набор ключей и как часто можно перечитывать его
+// при встрече неизвестного kid (защита AuthService от потока мусорных токенов)
+const (
+	jwksRefreshInterval    = 10 * time.Minute
+	jwksMinRefreshInterval = 10 * time.Second
+)
+
+// JWKSValidator проверяет подпись токена локально по ключам из AuthService.GetPublicKeys.
+// Не видит Logout до истечения access-токена - для этого есть RemoteValidator.
+// API-ключи не подписаны, их всегда проверяет AuthService.ValidateToken.
+type JWKSValidator struct {
+	client authpb.AuthServiceClient
+	keys   *jwks.KeySet
+
+	mu          sync.Mutex
+	lastRefresh time.Time
+}
+
+func NewJWKSValidator(client authpb.AuthServiceClient) *JWKSValidator {
+	return &JWKSValidator{client: client, keys: jwks.NewKeySet()}
+}
+
+func (v *JWKSValidator) Validate(ctx context.Context, token string) (*Identity, error) {
+	if IsAPIKey(token) {
+		return NewRemoteValidator(v.client).Validate(ctx, token)
+	}
+	if err := v.refresh(ctx, jwksRefreshInterval); err != nil {
+		log.Printf("Не удалось обновить публичные ключи AuthService: %v", err)
+	}
+
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(token, claims, v.keys.Keyfunc)
+	if err != nil {
+		// Токен мог быть подписан только что созданным ключом
+		var vErr *jwt.ValidationError
+		if !errors.As(err, &vErr) || !errors.Is(vErr.Inner, jwks.ErrUnknownKey) {
+			return nil, ErrInvalidToken
+		}
+		if err := v.refresh(ctx, jwksMinRefreshInterval); err != nil {
+			return nil, err
+		}
+		claims = &Claims{}
+		if _, err := jwt.ParseWithClaims(token, claims, v.keys.Keyfunc); err != nil {
+			return nil, ErrInvalidToken
+		}
+	}
+
+	return &Identity{UserID: claims.UserID, Username: claims.Username, SessionID: claims.SessionID, Role: claims.Role}, nil
+}
+
+// refresh перечитывает ключи, если с прошлой загрузки прошло больше maxAge
+func (v *JWKSValidator) refresh(ctx context.Context, maxAge time.Duration) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if time.Since(v.lastRefresh) < maxAge {
+		return nil
+	}
+	v.lastRefresh = time.Now()
+
+	resp, err := v.client.GetPublicKeys(ctx, &authpb.GetPublicKeysRequest{})
+	if err != nil {
+		return err
+	}
+
+	keys := make([]jwks.Key, 0, len(resp.Keys))
+	for _, k := range resp.Keys {
+		keys = append(keys, jwks.Key{
+			Kid: k.Kid,
+			Kty: k.Kty,
+			Alg: k.Alg,
+			Use: k.Use,
+			N:   k.N,
+			E:   k.E,
+			Crv: k.Crv,
+			X:   k.X,
+		})
+	}
+	return v.keys.Replace(keys)
+}
+
+// RemoteValidator проверяет каждый токен вызовом AuthService.ValidateToken
+type RemoteValidator struct {
+	client authpb.AuthServiceClient
+}
+
+func NewRemoteValidator(client authpb.AuthServiceClient) *RemoteValidator {
+	return &RemoteValidator{client: client}
+}
+
+func (v *RemoteValidator) Validate(ctx context.Context, token string) (*Identity, error) {
+	resp, err := v.client.ValidateToken(ctx, &authpb.ValidateTokenRequest{Token: token})
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Valid {
+		return nil, ErrInvalidToken
+	}
+	return &Identity{
+		UserID:           resp.UserId,
+		Username:         resp.Username,
+		SessionID:        resp.SessionId,
+		Role:             resp.Role,
+		ServiceAccountID: resp.ServiceAccountId,
+		Scopes:           resp.Scopes,
+	}, nil
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	authint "fin-trans/auth_interceptor_package"
+	authpb "fin-trans/auth_service/proto"
+	"fin-trans/auth_service/store"
+	models "fin-trans/models_package"
+)
+
+// UnlockAccount снимает блокировку входа с пользователя и/или IP
+func (s *server) UnlockAccount(ctx context.Context, req *authpb.UnlockAccountRequest) (*authpb.UnlockAccountResponse, error) {
+	if req.Username == "" && req.Ip == "" {
+		return nil, status.Error(codes.InvalidArgument, "username or ip is required")
+	}
+
+	var keys []string
+	if req.Username != "" {
+		keys = append(keys, userLockKey(req.Username))
+	}
+	if req.Ip != "" {
+		keys = append(keys, ipLockKey(req.Ip))
+	}
+	unlocked := false
+	for _, key := range keys {
+		exists, err := s.limiter.unlock(ctx, key)
+		if err != nil {
+			log.Printf("Ошибка при снятии блокировки %s: %v", key, err)
+			return nil, status.Error(codes.Internal, "Could not unlock")
+		}
+		unlocked = unlocked || exists
+	}
+	if !unlocked {
+		return &authpb.UnlockAccountResponse{Success: false, Message: "No failed attempts recorded"}, nil
+	}
+	s.auditCaller(ctx, auditAccountUnlock, models.AuditOutcomeSuccess, fmt.Sprintf("username=%q ip=%q", req.Username, req.Ip))
+	return &authpb.UnlockAccountResponse{Success: true, Message: "Unlocked"}, nil
+}
+
+func (s *server) ListUsers(ctx context.Context, req *authpb.ListUsersRequest) (*authpb.ListUsersResponse, error) {
+	users, err := s.users.ListUsers(ctx)
+	if err != nil {
+		log.Printf("Ошибка при получении списка пользователей: %v", err)
+		return nil, status.Error(codes.Internal, "Could not load users")
+	}
+
+	resp := &authpb.ListUsersResponse{}
+	for i := range users {
+		resp.Users = append(resp.Users, userInfo(&users[i]))
+	}
+	return resp, nil
+}
+
+// GetUser ищет пользователя по id или, если id не задан, по имени.
+// Нужен другим сервисам, чтобы привязывать данные к настоящему user_id.
+func (s *server) GetUser(ctx context.Context, req *authpb.GetUserRequest) (*authpb.UserInfo, error) {
+	var user *models.User
+	var err error
+	switch {
+	case req.UserId != 0:
+		user, err = s.users.GetUserByID(ctx, req.UserId)
+	case req.Username != "":
+		user, err = s.users.GetUserByUsername(ctx, req.Username)
+	default:
+		return nil, status.Error(codes.InvalidArgument, "user_id or username is required")
+	}
+	if errors.Is(err, store.ErrUserNotFound) {
+		return nil, status.Error(codes.NotFound, "User not found")
+	}
+	if err != nil {
+		log.Printf("Ошибка при получении пользователя: %v", err)
+		return nil, status.Error(codes.Internal, "Could not load user")
+	}
+	return userInfo(user), nil
+}
+
+func userInfo(user *models.User) *authpb.UserInfo {
+	return &authpb.UserInfo{
+		UserId:    user.ID,
+		Username:  user.Username,
+		Role:      user.Role,
+		Status:    user.Status,
+		CreatedAt: user.CreatedAt.Unix(),
+	}
+}
+
+// SetUserRole меняет роль. Сессии пользователя завершаются, чтобы новые токены несли новую роль.
+func (s *server) SetUserRole(ctx context.Context, req *authpb.SetUserRoleRequest) (*authpb.SetUserRoleResponse, error) {
+	if !authint.ValidRole(req.Role) {
+		return nil, status.Error(codes.InvalidArgument, "unknown role")
+	}
+	// Администратор не может случайно лишить себя прав
+	if caller, _ := authint.FromContext(ctx); caller != nil && !caller.Service && caller.UserID == req.UserId {
+		return nil, status.Error(codes.FailedPrecondition, "cannot change your own role")
+	}
+
+	err := s.users.SetUserRole(ctx, req.UserId, req.Role)
+	if errors.Is(err, store.ErrUserNotFound) {
+		return &authpb.SetUserRoleResponse{Success: false, Message: "User not found"}, nil
+	}
+	if err != nil {
+		log.Printf("Ошибка при смене роли пользователя %d: %v", req.UserId, err)
+		return nil, status.Error(codes.Internal, "Could not update role")
+	}
+	if _, err := s.revokeUserSessions(ctx, req.UserId, ""); err != nil {
+		log.Printf("Ошибка при отзыве сессий пользователя %d: %v", req.UserId, err)
+	}
+	s.auditCaller(ctx, auditRoleChange, models.AuditOutcomeSuccess, fmt.Sprintf("user %d role %s", req.UserId, req.Role))
+	return &authpb.SetUserRoleResponse{Success: true, Message: "Role updated"}, nil
+}
+
+// SetUserStatus блокирует или разблокирует учётную запись. Блокировка завершает все сессии.
+func (s *server) SetUserStatus(ctx context.Context, req *authpb.SetUserStatusRequest) (*authpb.SetUserStatusResponse, error) {
+	if req.Status != models.UserStatusActive && req.Status != models.UserStatusBlocked {
+		return nil, status.Error(codes.InvalidArgument, "unknown status")
+	}
+	if caller, _ := authint.FromContext(ctx); caller != nil && !caller.Service && caller.UserID == req.UserId {
+		return nil, status.Error(codes.FailedPrecondition, "cannot change your own status")
+	}
+
+	err := s.users.SetUserStatus(ctx, req.UserId, req.Status)
+	if errors.Is(err, store.ErrUserNotFound) {
+		return &authpb.SetUserStatusResponse{Success: false, Message: "User not found"}, nil
+	}
+	if err != nil {
+		log.Printf("Ошибка при смене статуса пользователя %d: %v", req.UserId, err)
+		return nil, status.Error(codes.Internal, "Could not update status")
+	}
+	if req.Status == models.UserStatusBlocked {
+		if _, err := s.revokeUserSessions(ctx, req.UserId, ""); err != nil {
+			log.Printf("Ошибка при отзыве сессий пользователя %d: %v", req.UserId, err)
+		}
+	}
+	s.auditCaller(ctx, auditStatusChange, models.AuditOutcomeSuccess, fmt.Sprintf("user %d status %s", req.UserId, req.Status))
+	return &authpb.SetUserStatusResponse{Success: true, Message: "Status updated"}, nil
+}
+
+// bootstrapAdmins назначает роль admin существующим пользователям из AUTH_ADMIN_USERS,
+// чтобы в новой установке было кому раздавать роли
+func bootstrapAdmins(ctx context.Context, users store.UserStore, usernames []string) {
+	for _, username := range usernames {
+		user, err := users.GetUserByUsername(ctx, username)
+		if err != nil {
+			log.Printf("Не удалось назначить администратора %s: %v", username, err)
+			continue
+		}
+		if user.Role == models.RoleAdmin {
+			continue
+		}
+		if err := users.SetUserRole(ctx, user.ID, models.RoleAdmin); err != nil {
+			log.Printf("Не удалось назначить администратора %s: %v", username, err)
+			continue
+		}
+		log.Printf("Пользователь %s назначен администратором", username)
+	}
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	authint "fin-trans/auth_interceptor_package"
+	authpb "fin-trans/auth_service/proto"
+	"fin-trans/auth_service/store"
+	models "fin-trans/models_package"
+)
+
+// События журнала аудита
+const (
+	auditRegister             = "register"
+	auditLogin                = "login"
+	auditSecondFactor         = "second_factor"
+	auditLogout               = "logout"
+	auditRefresh              = "refresh"
+	auditPasswordChange       = "password_change"
+	auditPasswordResetRequest = "password_reset_request"
+	auditPasswordReset        = "password_reset"
+	auditTOTPEnroll           = "totp_enroll"
+	auditTOTPConfirm          = "totp_confirm"
+	auditSessionRevoke        = "session_revoke"
+	auditAccountUnlock        = "account_unlock"
+	auditRoleChange           = "role_change"
+	auditStatusChange         = "status_change"
+	auditServiceAccountCreate = "service_account_create"
+	auditAPIKeyCreate         = "api_key_create"
+	auditAPIKeyRevoke         = "api_key_revoke"
+	auditStepUpIssue          = "step_up_issue"
+	auditStepUpVerify         = "step_up_verify"
+)
+
+const (
+	defaultAuditLimit = 100
+	maxAuditLimit     = 1000
+)
+
+// audit дописывает событие в журнал, добавляя время, IP и User-Agent клиента.
+// Сбой записи не отменяет операцию, но попадает в лог сервиса.
+func (s *server) audit(ctx context.Context, event models.AuditEvent) {
+	client := clientInfoFromContext(ctx)
+	event.Timestamp = time.Now()
+	event.IP = client.IP
+	event.UserAgent = client.UserAgent
+
+	if err := s.auditLog.AppendAuditEvent(ctx, &event); err != nil {
+		log.Printf("Ошибка при записи события аудита %s (%s, %s): %v", event.Event, event.Username, event.Outcome, err)
+	}
+}
+
+// auditCaller записывает событие от имени аутентифицированного вызывающего
+func (s *server) auditCaller(ctx context.Context, event, outcome, detail string) {
+	entry := models.AuditEvent{Event: event, Outcome: outcome, Detail: detail}
+	if caller, ok := authint.FromContext(ctx); ok {
+		entry.UserID = caller.UserID
+		entry.Username = caller.Username
+	}
+	s.audit(ctx, entry)
+}
+
+// ListAuditEvents возвращает журнал от новых записей к старым.
+// С verify_chain дополнительно проверяется цепочка хешей всего журнала.
+func (s *server) ListAuditEvents(ctx context.Context, req *authpb.ListAuditEventsRequest) (*authpb.ListAuditEventsResponse, error) {
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultAuditLimit
+	}
+	if limit > maxAuditLimit {
+		limit = maxAuditLimit
+	}
+
+	filter := store.AuditFilter{
+		Username: req.Username,
+		Event:    req.Event,
+		Outcome:  req.Outcome,
+		BeforeID: req.BeforeId,
+		Limit:    limit,
+	}
+	if req.Since > 0 {
+		filter.Since = time.Unix(req.Since, 0)
+	}
+	if req.Until > 0 {
+		filter.Until = time.Unix(req.Until, 0)
+	}
+
+	events, err := s.auditLog.ListAuditEvents(ctx, filter)
+	if err != nil {
+		log.Printf("Ошибка при чтении журнала аудита: %v", err)
+		return nil, status.Error(codes.Internal, "Could not load audit events")
+	}
+
+	resp := &authpb.ListAuditEventsResponse{}
+	for _, e := range events {
+		resp.Events = append(resp.Events, &authpb.AuditEvent{
+			Id:        e.ID,
+			Timestamp: e.Timestamp.Unix(),
+			Event:     e.Event,
+			UserId:    e.UserID,
+			Username:  e.Username,
+			Ip:        e.IP,
+			UserAgent: e.UserAgent,
+			Outcome:   e.Outcome,
+			Detail:    e.Detail,
+			PrevHash:  e.PrevHash,
+			Hash:      e.Hash,
+		})
+	}
+
+	if req.VerifyChain {
+		chain, err := s.auditLog.ListAuditChain(ctx)
+		if err != nil {
+			log.Printf("Ошибка при чтении журнала аудита: %v", err)
+			return nil, status.Error(codes.Internal, "Could not load audit events")
+		}
+		resp.FirstInvalidId = store.VerifyAuditChain(chain)
+		resp.ChainValid = resp.FirstInvalidId == 0
+		if !resp.ChainValid {
+			log.Printf("Цепочка журнала аудита нарушена на записи %d", resp.FirstInvalidId)
+		}
+	}
+	return resp, nil
+}
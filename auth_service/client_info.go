@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// clientInfo - откуда пришёл запрос
+type clientInfo struct {
+	IP        string
+	UserAgent string
+}
+
+// clientInfoFromContext достаёт адрес и User-Agent клиента.
+// grpc-gateway передаёт их в x-forwarded-for и grpcgateway-user-agent;
+// x-forwarded-for учитываем только от локального шлюза, иначе адрес легко подделать.
+// Шлюз сохраняет x-forwarded-for клиента и дописывает в конец адрес, с которого
+// к нему подключились, поэтому верить можно только последнему адресу.
+func clientInfoFromContext(ctx context.Context) clientInfo {
+	var info clientInfo
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		host, _, err := net.SplitHostPort(p.Addr.String())
+		if err != nil {
+			host = p.Addr.String()
+		}
+		info.IP = host
+	}
+	if ip := net.ParseIP(info.IP); ip != nil && ip.IsLoopback() {
+		if forwarded := md.Get("x-forwarded-for"); len(forwarded) > 0 {
+			hops := strings.Split(forwarded[len(forwarded)-1], ",")
+			if last := strings.TrimSpace(hops[len(hops)-1]); last != "" {
+				info.IP = last
+			}
+		}
+	}
+
+	if ua := md.Get("grpcgateway-user-agent"); len(ua) > 0 {
+		info.UserAgent = ua[0]
+	} else if ua := md.Get("user-agent"); len(ua) > 0 {
+		info.UserAgent = ua[0]
+	}
+	return info
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"fin-trans/auth_service/passhash"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// tokenConfig - время жизни выдаваемых токенов
+type tokenConfig struct {
+	AccessTokenTTL   time.Duration
+	RefreshTokenTTL  time.Duration
+	PasswordResetTTL time.Duration
+}
+
+func loadTokenConfig() tokenConfig {
+	return tokenConfig{
+		AccessTokenTTL:   envDuration("AUTH_ACCESS_TOKEN_TTL", 15*time.Minute),
+		RefreshTokenTTL:  envDuration("AUTH_REFRESH_TOKEN_TTL", 30*24*time.Hour),
+		PasswordResetTTL: envDuration("AUTH_PASSWORD_RESET_TTL", 30*time.Minute),
+	}
+}
+
+// redisConfig - Redis для общего между репликами хранилища сессий
+type redisConfig struct {
+	Addr     string
+	Password string
+}
+
+func loadRedisConfig() redisConfig {
+	return redisConfig{
+		Addr:     envString("AUTH_REDIS_ADDR", "localhost:6379"),
+		Password: os.Getenv("AUTH_REDIS_PASSWORD"),
+	}
+}
+
+// keyConfig - настройки ключей подписи JWT
+type keyConfig struct {
+	Alg              string        // RS256 или EdDSA
+	Dir              string        // общий для реплик каталог с ключами, обязателен при сессиях в Redis
+	RotationInterval time.Duration // 0 - автоматическая ротация выключена
+	ReloadInterval   time.Duration
+}
+
+func loadKeyConfig() keyConfig {
+	return keyConfig{
+		Alg:              envString("AUTH_JWT_ALG", "RS256"),
+		Dir:              os.Getenv("AUTH_KEYS_DIR"),
+		RotationInterval: envDuration("AUTH_KEY_ROTATION_INTERVAL", 0),
+		ReloadInterval:   envDuration("AUTH_KEYS_RELOAD_INTERVAL", time.Minute),
+	}
+}
+
+func envString(name, def string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return def
+}
+
+// envDuration читает длительность вида "15m" из переменной окружения
+func envDuration(name string, def time.Duration) time.Duration {
+	value := os.Getenv(name)
+	if value == "" {
+		return def
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		log.Printf("Неверное значение %s=%q, используется %v", name, value, def)
+		return def
+	}
+	return d
+}
+
+// twoFactorConfig - настройки двухфакторной аутентификации
+type twoFactorConfig struct {
+	Issuer       string        // название сервиса в приложении-аутентификаторе
+	ChallengeTTL time.Duration // сколько ждать второй фактор после ввода пароля
+	StepUpTTL    time.Duration // сколько действует код подтверждения операции
+}
+
+func loadTwoFactorConfig() twoFactorConfig {
+	return twoFactorConfig{
+		Issuer:       envString("AUTH_TOTP_ISSUER", "FinTrans"),
+		ChallengeTTL: envDuration("AUTH_2FA_CHALLENGE_TTL", 5*time.Minute),
+		StepUpTTL:    envDuration("AUTH_STEP_UP_TTL", 5*time.Minute),
+	}
+}
+
+// lockoutConfig - защита входа от перебора паролей
+type lockoutConfig struct {
+	UserThreshold int           // неудачных попыток на имя пользователя до первой блокировки
+	IPThreshold   int           // то же для IP; выше, так как за одним IP бывает много клиентов
+	BaseDelay     time.Duration // первая блокировка, дальше удваивается
+	MaxDelay      time.Duration
+	ResetAfter    time.Duration // счётчик сбрасывается, если столько времени не было неудач
+}
+
+func loadLockoutConfig() lockoutConfig {
+	return lockoutConfig{
+		UserThreshold: envInt("AUTH_LOCKOUT_USER_THRESHOLD", 5),
+		IPThreshold:   envInt("AUTH_LOCKOUT_IP_THRESHOLD", 20),
+		BaseDelay:     envDuration("AUTH_LOCKOUT_BASE_DELAY", 30*time.Second),
+		MaxDelay:      envDuration("AUTH_LOCKOUT_MAX_DELAY", 15*time.Minute),
+		ResetAfter:    envDuration("AUTH_LOCKOUT_RESET_AFTER", time.Hour),
+	}
+}
+
+// passwordPolicy - требования к новым паролям при регистрации, смене и сбросе
+type passwordPolicy struct {
+	MinLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+}
+
+func loadPasswordPolicy() passwordPolicy {
+	return passwordPolicy{
+		MinLength:     envInt("AUTH_PASSWORD_MIN_LENGTH", 8),
+		RequireUpper:  envBool("AUTH_PASSWORD_REQUIRE_UPPER", false),
+		RequireLower:  envBool("AUTH_PASSWORD_REQUIRE_LOWER", false),
+		RequireDigit:  envBool("AUTH_PASSWORD_REQUIRE_DIGIT", true),
+		RequireSymbol: envBool("AUTH_PASSWORD_REQUIRE_SYMBOL", false),
+	}
+}
+
+// loadPasswordHashParams - алгоритм и параметры хеширования новых паролей.
+// Старые хеши продолжают проверяться и пересчитываются при следующем входе.
+func loadPasswordHashParams() passhash.Params {
+	return passhash.Params{
+		Algorithm:     envString("AUTH_PASSWORD_HASH", passhash.Argon2id),
+		BcryptCost:    envInt("AUTH_BCRYPT_COST", bcrypt.DefaultCost),
+		Argon2Time:    uint32(envInt("AUTH_ARGON2_TIME", 3)),
+		Argon2Memory:  uint32(envInt("AUTH_ARGON2_MEMORY_KIB", 64*1024)),
+		Argon2Threads: uint8(envInt("AUTH_ARGON2_THREADS", 2)),
+		Argon2SaltLen: 16,
+		Argon2KeyLen:  32,
+	}
+}
+
+// cookieConfig - выдача токенов REST-шлюзом в HttpOnly cookie для браузерных клиентов
+type cookieConfig struct {
+	Enabled bool
+	Secure  bool   // cookie только по HTTPS; выключается для локальной разработки
+	Domain  string // пустой - cookie привязана к хосту шлюза
+}
+
+func loadCookieConfig() cookieConfig {
+	return cookieConfig{
+		Enabled: envBool("AUTH_COOKIE_ENABLED", false),
+		Secure:  envBool("AUTH_COOKIE_SECURE", true),
+		Domain:  os.Getenv("AUTH_COOKIE_DOMAIN"),
+	}
+}
+
+// envBool читает "true"/"false" из переменной окружения
+func envBool(name string, def bool) bool {
+	value := os.Getenv(name)
+	if value == "" {
+		return def
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("Неверное значение %s=%q, используется %v", name, value, def)
+		return def
+	}
+	return b
+}
+
+// envInt читает положительное число из переменной окружения
+func envInt(name string, def int) int {
+	value := os.Getenv(name)
+	if value == "" {
+		return def
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		log.Printf("Неверное значение %s=%q, используется %d", name, value, def)
+		return def
+	}
+	return n
+}
+
+// adminUsernames - пользователи, которым при старте назначается роль admin
+func adminUsernames() []string {
+	var admins []string
+	for _, name := range strings.Split(os.Getenv("AUTH_ADMIN_USERS"), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			admins = append(admins, name)
+		}
+	}
+	return admins
+}
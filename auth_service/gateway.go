@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	authpb "fin-trans/auth_service/proto"
+)
+
+// Cookie с токенами для браузеров. Refresh-токен уходит только на пути /v1/auth/.
+const (
+	accessCookieName  = "fintrans_access_token"
+	refreshCookieName = "fintrans_refresh_token"
+	refreshCookiePath = "/v1/auth/"
+	refreshPath       = "/v1/auth/refresh"
+	logoutPath        = "/v1/auth/logout"
+
+	maxGatewayBodyBytes = 1 << 20
+)
+
+// tokenCookies кладёт токены из ответов шлюза в HttpOnly cookie и принимает их обратно,
+// так что JavaScript страницы токенов не видит. SameSite=Strict защищает от CSRF.
+type tokenCookies struct {
+	cfg        cookieConfig
+	refreshTTL time.Duration
+}
+
+// muxOptions подключает cookie к grpc-gateway
+func (c tokenCookies) muxOptions() []runtime.ServeMuxOption {
+	return []runtime.ServeMuxOption{
+		runtime.WithMetadata(c.metadata),
+		runtime.WithForwardResponseOption(c.forwardResponse),
+	}
+}
+
+// metadata передаёт access-токен из cookie, если клиент не прислал заголовок Authorization
+func (c tokenCookies) metadata(ctx context.Context, r *http.Request) metadata.MD {
+	if r.Header.Get("Authorization") != "" {
+		return nil
+	}
+	cookie, err := r.Cookie(accessCookieName)
+	if err != nil || cookie.Value == "" {
+		return nil
+	}
+	return metadata.Pairs("authorization", "Bearer "+cookie.Value)
+}
+
+// forwardResponse ставит cookie после входа и ротации токенов. Сами токены из тела
+// ответа убираются: вызывается до сериализации, и в JSON они уже не попадают.
+func (c tokenCookies) forwardResponse(ctx context.Context, w http.ResponseWriter, m proto.Message) error {
+	switch resp := m.(type) {
+	case *authpb.LoginResponse:
+		if resp.Success {
+			c.set(w, resp.AccessToken, resp.RefreshToken, resp.ExpiresIn)
+			resp.AccessToken, resp.RefreshToken = "", ""
+		}
+	case *authpb.RefreshTokenResponse:
+		if resp.Success {
+			c.set(w, resp.AccessToken, resp.RefreshToken, resp.ExpiresIn)
+			resp.AccessToken, resp.RefreshToken = "", ""
+		}
+	}
+	return nil
+}
+
+func (c tokenCookies) set(w http.ResponseWriter, accessToken, refreshToken string, expiresIn int64) {
+	http.SetCookie(w, c.cookie(accessCookieName, "/", accessToken, int(expiresIn)))
+	http.SetCookie(w, c.cookie(refreshCookieName, refreshCookiePath, refreshToken, int(c.refreshTTL.Seconds())))
+}
+
+func (c tokenCookies) clear(w http.ResponseWriter) {
+	http.SetCookie(w, c.cookie(accessCookieName, "/", "", -1))
+	http.SetCookie(w, c.cookie(refreshCookieName, refreshCookiePath, "", -1))
+}
+
+func (c tokenCookies) cookie(name, path, value string, maxAge int) *http.Cookie {
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     path,
+		Domain:   c.cfg.Domain,
+		MaxAge:   maxAge,
+		Secure:   c.cfg.Secure,
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+	}
+}
+
+// handler подставляет refresh-токен из cookie в тело POST /v1/auth/refresh,
+// если клиент не передал его сам. POST /v1/auth/logout стирает cookie при любом
+// результате: с истёкшим access-токеном Logout не пройдёт, а cookie остались бы.
+func (c tokenCookies) handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.Path == logoutPath {
+			c.clear(w)
+		}
+		if r.Method == http.MethodPost && r.URL.Path == refreshPath {
+			if cookie, err := r.Cookie(refreshCookieName); err == nil && cookie.Value != "" {
+				if err := withRefreshToken(r, cookie.Value); err != nil {
+					http.Error(w, "invalid request body", http.StatusBadRequest)
+					return
+				}
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func withRefreshToken(r *http.Request, token string) error {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxGatewayBodyBytes))
+	r.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	req := &authpb.RefreshTokenRequest{}
+	if len(bytes.TrimSpace(body)) > 0 {
+		if err := protojson.Unmarshal(body, req); err != nil {
+			return err
+		}
+	}
+	if req.RefreshToken == "" {
+		req.RefreshToken = token
+	}
+
+	body, err = protojson.Marshal(req)
+	if err != nil {
+		return err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	r.ContentLength = int64(len(body))
+	return nil
+}
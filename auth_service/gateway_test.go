@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	authpb "fin-trans/auth_service/proto"
+)
+
+func TestForwardResponseMovesTokensToCookies(t *testing.T) {
+	c := tokenCookies{cfg: cookieConfig{Enabled: true, Secure: true}, refreshTTL: time.Hour}
+	tests := []struct {
+		name    string
+		resp    proto.Message
+		cookies int
+	}{
+		{"login", &authpb.LoginResponse{Success: true, AccessToken: "a", RefreshToken: "r", ExpiresIn: 60}, 2},
+		{"refresh", &authpb.RefreshTokenResponse{Success: true, AccessToken: "a", RefreshToken: "r", ExpiresIn: 60}, 2},
+		{"second factor required", &authpb.LoginResponse{SecondFactorRequired: true, ChallengeId: "c"}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			if err := c.forwardResponse(context.Background(), w, tt.resp); err != nil {
+				t.Fatal(err)
+			}
+			cookies := w.Result().Cookies()
+			if len(cookies) != tt.cookies {
+				t.Fatalf("got %d cookies, want %d", len(cookies), tt.cookies)
+			}
+			for _, cookie := range cookies {
+				if !cookie.HttpOnly || cookie.Value == "" {
+					t.Fatalf("cookie %s = %+v, want HttpOnly with a token", cookie.Name, cookie)
+				}
+			}
+			var access, refresh string
+			switch resp := tt.resp.(type) {
+			case *authpb.LoginResponse:
+				access, refresh = resp.AccessToken, resp.RefreshToken
+			case *authpb.RefreshTokenResponse:
+				access, refresh = resp.AccessToken, resp.RefreshToken
+			}
+			if access != "" || refresh != "" {
+				t.Fatalf("response body still carries tokens: %v", tt.resp)
+			}
+		})
+	}
+}
+
+// Cookie стираются, даже если Logout отклонён из-за истёкшего access-токена
+func TestHandlerClearsCookiesOnLogout(t *testing.T) {
+	c := tokenCookies{cfg: cookieConfig{Enabled: true}, refreshTTL: time.Hour}
+	rejected := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "token expired", http.StatusUnauthorized)
+	})
+
+	w := httptest.NewRecorder()
+	c.handler(rejected).ServeHTTP(w, httptest.NewRequest(http.MethodPost, logoutPath, nil))
+	cleared := map[string]bool{}
+	for _, cookie := range w.Result().Cookies() {
+		if cookie.MaxAge < 0 && cookie.Value == "" {
+			cleared[cookie.Name] = true
+		}
+	}
+	if !cleared[accessCookieName] || !cleared[refreshCookieName] {
+		t.Fatalf("logout cookies = %v, want both token cookies cleared", w.Result().Cookies())
+	}
+}
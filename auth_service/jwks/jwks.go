@@ -0,0 +1,160 @@
+// Package jwks описывает публичные ключи сервиса аутентификации в формате JWK (RFC 7517)
+// и позволяет другим сервисам проверять JWT локально, без вызова ValidateToken.
+package jwks
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+var (
+	ErrUnknownKey         = errors.New("неизвестный идентификатор ключа (kid)")
+	ErrUnsupportedKeyType = errors.New("неподдерживаемый тип ключа")
+)
+
+// Key - публичный ключ в формате JWK
+type Key struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Alg string `json:"alg"`
+	Use string `json:"use,omitempty"`
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// OKP (Ed25519)
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// Alg возвращает алгоритм подписи JWT для ключа
+func Alg(publicKey crypto.PublicKey) (string, error) {
+	switch publicKey.(type) {
+	case *rsa.PublicKey:
+		return jwt.SigningMethodRS256.Alg(), nil
+	case ed25519.PublicKey:
+		return SigningMethodEdDSA.Alg(), nil
+	}
+	return "", ErrUnsupportedKeyType
+}
+
+// KeyID вычисляет kid как отпечаток публичного ключа
+func KeyID(publicKey crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:8]), nil
+}
+
+// FromPublicKey кодирует публичный ключ в JWK
+func FromPublicKey(kid string, publicKey crypto.PublicKey) (Key, error) {
+	alg, err := Alg(publicKey)
+	if err != nil {
+		return Key{}, err
+	}
+
+	key := Key{Kid: kid, Alg: alg, Use: "sig"}
+	switch pub := publicKey.(type) {
+	case *rsa.PublicKey:
+		key.Kty = "RSA"
+		key.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+		key.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+	case ed25519.PublicKey:
+		key.Kty = "OKP"
+		key.Crv = "Ed25519"
+		key.X = base64.RawURLEncoding.EncodeToString(pub)
+	}
+	return key, nil
+}
+
+// PublicKey декодирует JWK обратно в публичный ключ
+func (k Key) PublicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("ключ %s: неверный модуль: %w", k.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("ключ %s: неверная экспонента: %w", k.Kid, err)
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, ErrUnsupportedKeyType
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("ключ %s: неверный публичный ключ Ed25519", k.Kid)
+		}
+		return ed25519.PublicKey(x), nil
+	}
+	return nil, ErrUnsupportedKeyType
+}
+
+// KeySet - набор публичных ключей для проверки подписи JWT по kid
+type KeySet struct {
+	mu   sync.RWMutex
+	keys map[string]crypto.PublicKey
+}
+
+func NewKeySet() *KeySet {
+	return &KeySet{keys: make(map[string]crypto.PublicKey)}
+}
+
+// Replace заменяет набор ключей целиком (например, после повторной загрузки JWKS)
+func (s *KeySet) Replace(keys []Key) error {
+	decoded := make(map[string]crypto.PublicKey, len(keys))
+	for _, k := range keys {
+		pub, err := k.PublicKey()
+		if err != nil {
+			return err
+		}
+		decoded[k.Kid] = pub
+	}
+
+	s.mu.Lock()
+	s.keys = decoded
+	s.mu.Unlock()
+	return nil
+}
+
+// Keyfunc выбирает ключ по заголовку kid для jwt.Parse.
+// Алгоритм токена должен совпадать с типом ключа, иначе возможна подмена alg.
+func (s *KeySet) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	s.mu.RLock()
+	pub, ok := s.keys[kid]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+
+	return CheckAlg(token, pub)
+}
+
+// CheckAlg проверяет, что токен подписан алгоритмом, соответствующим ключу
+func CheckAlg(token *jwt.Token, publicKey crypto.PublicKey) (crypto.PublicKey, error) {
+	alg, err := Alg(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	if token.Method.Alg() != alg {
+		return nil, fmt.Errorf("неожиданный алгоритм подписи %s", token.Method.Alg())
+	}
+	return publicKey, nil
+}
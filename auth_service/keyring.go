@@ -0,0 +1,281 @@
+package main
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+
+	"fin-trans/auth_service/jwks"
+)
+
+// signingKey - закрытый ключ подписи JWT
+type signingKey struct {
+	kid       string
+	method    jwt.SigningMethod
+	private   crypto.Signer
+	createdAt time.Time
+	retiredAt time.Time // нулевое значение - ключ активен
+}
+
+// keyRing хранит активный ключ подписи и выведенные из оборота ключи.
+// Выведенные ключи продолжают публиковаться, пока не истекут подписанные ими
+// access-токены, поэтому ротация не разлогинивает пользователей.
+type keyRing struct {
+	mu        sync.RWMutex
+	alg       string        // алгоритм для новых ключей: RS256 или EdDSA
+	dir       string        // каталог с PEM-файлами ключей, пустой - ключи только в памяти
+	retention time.Duration // сколько публиковать ключ после вывода из оборота
+	active    *signingKey
+	keys      map[string]*signingKey
+}
+
+func newKeyRing(cfg keyConfig, retention time.Duration) (*keyRing, error) {
+	if cfg.Alg != jwt.SigningMethodRS256.Alg() && cfg.Alg != jwks.SigningMethodEdDSA.Alg() {
+		return nil, fmt.Errorf("неподдерживаемый алгоритм подписи %s", cfg.Alg)
+	}
+
+	r := &keyRing{
+		alg:       cfg.Alg,
+		dir:       cfg.Dir,
+		retention: retention,
+		keys:      make(map[string]*signingKey),
+	}
+
+	if r.dir != "" {
+		if err := r.load(); err != nil {
+			return nil, err
+		}
+	}
+	if r.active == nil {
+		if err := r.rotate(); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// run периодически ротирует ключи и перечитывает общий каталог ключей,
+// чтобы реплики видели ключи, созданные друг другом
+func (r *keyRing) run(cfg keyConfig) {
+	var rotateC, reloadC <-chan time.Time
+	if cfg.RotationInterval > 0 {
+		rotateC = time.NewTicker(cfg.RotationInterval).C
+	}
+	if r.dir != "" {
+		reloadC = time.NewTicker(cfg.ReloadInterval).C
+	}
+
+	for {
+		select {
+		case <-rotateC:
+			if err := r.rotate(); err != nil {
+				log.Printf("Ошибка при ротации ключа подписи: %v", err)
+			}
+		case <-reloadC:
+			if err := r.load(); err != nil {
+				log.Printf("Ошибка при загрузке ключей подписи: %v", err)
+			}
+		}
+		r.prune()
+	}
+}
+
+// load читает ключи из каталога. Самый новый файл становится активным ключом,
+// остальные считаются выведенными из оборота в момент появления следующего.
+func (r *keyRing) load() error {
+	paths, err := filepath.Glob(filepath.Join(r.dir, "*.pem"))
+	if err != nil {
+		return err
+	}
+
+	var loaded []*signingKey
+	for _, path := range paths {
+		key, err := readKeyFile(path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		loaded = append(loaded, key)
+	}
+	if len(loaded) == 0 {
+		return nil
+	}
+
+	sort.Slice(loaded, func(i, j int) bool { return loaded[i].createdAt.Before(loaded[j].createdAt) })
+	for i := 0; i < len(loaded)-1; i++ {
+		loaded[i].retiredAt = loaded[i+1].createdAt
+	}
+
+	keys := make(map[string]*signingKey, len(loaded))
+	for _, key := range loaded {
+		keys[key.kid] = key
+	}
+
+	r.mu.Lock()
+	r.keys = keys
+	r.active = loaded[len(loaded)-1]
+	r.mu.Unlock()
+
+	r.prune()
+	return nil
+}
+
+// rotate создаёт новый активный ключ, предыдущий остаётся для проверки подписи
+func (r *keyRing) rotate() error {
+	key, err := generateSigningKey(r.alg)
+	if err != nil {
+		return err
+	}
+	if r.dir != "" {
+		if err := writeKeyFile(filepath.Join(r.dir, key.kid+".pem"), key); err != nil {
+			return err
+		}
+	}
+
+	r.mu.Lock()
+	if r.active != nil {
+		r.active.retiredAt = key.createdAt
+	}
+	r.active = key
+	r.keys[key.kid] = key
+	r.mu.Unlock()
+
+	log.Printf("Новый ключ подписи JWT: kid=%s alg=%s", key.kid, key.method.Alg())
+	return nil
+}
+
+// prune убирает ключи, которыми уже не может быть подписан ни один живой токен
+func (r *keyRing) prune() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for kid, key := range r.keys {
+		if !key.retiredAt.IsZero() && time.Since(key.retiredAt) > r.retention {
+			delete(r.keys, kid)
+		}
+	}
+}
+
+// sign подписывает claims активным ключом и проставляет kid в заголовок
+func (r *keyRing) sign(claims jwt.Claims) (string, error) {
+	r.mu.RLock()
+	key := r.active
+	r.mu.RUnlock()
+
+	token := jwt.NewWithClaims(key.method, claims)
+	token.Header["kid"] = key.kid
+	return token.SignedString(key.private)
+}
+
+// keyFunc используется в jwt.Parse для проверки токенов, выданных этим сервисом
+func (r *keyRing) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	r.mu.RLock()
+	key, ok := r.keys[kid]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, jwks.ErrUnknownKey
+	}
+
+	return jwks.CheckAlg(token, key.private.Public())
+}
+
+// publicKeys возвращает все опубликованные ключи в формате JWK
+func (r *keyRing) publicKeys() ([]jwks.Key, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	keys := make([]jwks.Key, 0, len(r.keys))
+	for _, key := range r.keys {
+		jwk, err := jwks.FromPublicKey(key.kid, key.private.Public())
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, jwk)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Kid < keys[j].Kid })
+	return keys, nil
+}
+
+func generateSigningKey(alg string) (*signingKey, error) {
+	var private crypto.Signer
+	var err error
+	switch alg {
+	case jwks.SigningMethodEdDSA.Alg():
+		_, private, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		private, err = rsa.GenerateKey(rand.Reader, 2048)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return newSigningKey(private, time.Now())
+}
+
+func newSigningKey(private crypto.Signer, createdAt time.Time) (*signingKey, error) {
+	var method jwt.SigningMethod
+	switch private.(type) {
+	case *rsa.PrivateKey:
+		method = jwt.SigningMethodRS256
+	case ed25519.PrivateKey:
+		method = jwks.SigningMethodEdDSA
+	default:
+		return nil, jwks.ErrUnsupportedKeyType
+	}
+
+	kid, err := jwks.KeyID(private.Public())
+	if err != nil {
+		return nil, err
+	}
+	return &signingKey{kid: kid, method: method, private: private, createdAt: createdAt}, nil
+}
+
+func readKeyFile(path string) (*signingKey, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("файл не содержит PEM-блока")
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	private, ok := parsed.(crypto.Signer)
+	if !ok {
+		return nil, jwks.ErrUnsupportedKeyType
+	}
+	return newSigningKey(private, info.ModTime())
+}
+
+func writeKeyFile(path string, key *signingKey) error {
+	der, err := x509.MarshalPKCS8PrivateKey(key.private)
+	if err != nil {
+		return err
+	}
+	data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return err
+	}
+	// Время файла используется при загрузке как время создания ключа
+	return os.Chtimes(path, key.createdAt, key.createdAt)
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"fin-trans/auth_service/store"
+)
+
+// loginLimiter считает неудачные входы по имени пользователя и по IP.
+// После порога каждая следующая неудача блокирует ключ на время, которое
+// удваивается до MaxDelay. Счётчики лежат в store: с Redis они общие для всех
+// реплик, в памяти - свои у каждой. Сбой хранилища не мешает входу, а только логируется.
+type loginLimiter struct {
+	cfg   lockoutConfig
+	store store.LockoutStore
+}
+
+func newLoginLimiter(cfg lockoutConfig, counters store.LockoutStore) *loginLimiter {
+	return &loginLimiter{cfg: cfg, store: counters}
+}
+
+func userLockKey(username string) string { return "user:" + username }
+func ipLockKey(ip string) string         { return "ip:" + ip }
+
+// check возвращает ошибку ResourceExhausted, если имя пользователя или IP заблокированы
+func (l *loginLimiter) check(ctx context.Context, username, ip string) error {
+	now := time.Now()
+	var wait time.Duration
+	for _, key := range []string{userLockKey(username), ipLockKey(ip)} {
+		until, err := l.store.LockedUntil(ctx, key)
+		if err != nil {
+			log.Printf("Ошибка при проверке блокировки %s: %v", key, err)
+			continue
+		}
+		if d := until.Sub(now); d > wait {
+			wait = d
+		}
+	}
+	if wait == 0 {
+		return nil
+	}
+	return lockedOutError(wait)
+}
+
+// fail учитывает неудачный вход
+func (l *loginLimiter) fail(ctx context.Context, username, ip string) {
+	l.record(ctx, userLockKey(username), l.cfg.UserThreshold)
+	if ip != "" {
+		l.record(ctx, ipLockKey(ip), l.cfg.IPThreshold)
+	}
+}
+
+// success сбрасывает счётчик пользователя. Счётчик IP не сбрасываем:
+// иначе перебор можно было бы перемежать входами в свою учётную запись.
+func (l *loginLimiter) success(ctx context.Context, username string) {
+	if _, err := l.store.Reset(ctx, userLockKey(username)); err != nil {
+		log.Printf("Ошибка при сбросе счётчика неудачных входов %s: %v", username, err)
+	}
+}
+
+// unlock снимает блокировку и обнуляет счётчик
+func (l *loginLimiter) unlock(ctx context.Context, key string) (bool, error) {
+	return l.store.Reset(ctx, key)
+}
+
+func (l *loginLimiter) record(ctx context.Context, key string, threshold int) {
+	failures, err := l.store.AddFailure(ctx, key, l.cfg.ResetAfter)
+	if err != nil {
+		log.Printf("Ошибка при учёте неудачного входа %s: %v", key, err)
+		return
+	}
+	if failures >= threshold {
+		if err := l.store.Lock(ctx, key, time.Now().Add(l.backoff(failures-threshold))); err != nil {
+			log.Printf("Ошибка при блокировке %s: %v", key, err)
+		}
+	}
+}
+
+// backoff - BaseDelay * 2^n, но не больше MaxDelay
+func (l *loginLimiter) backoff(n int) time.Duration {
+	delay := float64(l.cfg.BaseDelay) * math.Pow(2, float64(n))
+	if delay > float64(l.cfg.MaxDelay) {
+		return l.cfg.MaxDelay
+	}
+	return time.Duration(delay)
+}
+
+// lockedOutError - ResourceExhausted с RetryInfo, чтобы клиент знал, когда повторить
+func lockedOutError(wait time.Duration) error {
+	wait = wait.Truncate(time.Second) + time.Second
+	st := status.New(codes.ResourceExhausted, fmt.Sprintf("Too many failed login attempts, retry in %v", wait))
+	if detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(wait)}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
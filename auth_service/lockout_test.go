@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"fin-trans/auth_service/store"
+)
+
+func TestLoginLimiter(t *testing.T) {
+	ctx := context.Background()
+	cfg := lockoutConfig{UserThreshold: 3, IPThreshold: 5, BaseDelay: time.Minute, MaxDelay: 3 * time.Minute, ResetAfter: time.Hour}
+	counters := store.NewMemoryLockoutStore()
+	l := newLoginLimiter(cfg, counters)
+
+	locked := func(username, ip string) bool {
+		return status.Code(l.check(ctx, username, ip)) == codes.ResourceExhausted
+	}
+	for i := 0; i < 2; i++ {
+		l.fail(ctx, "alice", "10.0.0.1")
+	}
+	if locked("alice", "10.0.0.2") {
+		t.Fatalf("alice is locked out below the threshold")
+	}
+	l.fail(ctx, "alice", "10.0.0.1")
+	if !locked("alice", "10.0.0.2") {
+		t.Fatalf("alice is not locked out at the threshold")
+	}
+
+	// Вход в свою учётную запись сбрасывает счётчик пользователя, но не IP
+	l.success(ctx, "alice")
+	if locked("alice", "10.0.0.2") {
+		t.Fatalf("alice is still locked out after success")
+	}
+	l.fail(ctx, "bob", "10.0.0.1")
+	l.fail(ctx, "carol", "10.0.0.1")
+	if !locked("dave", "10.0.0.1") {
+		t.Fatalf("ip 10.0.0.1 is not locked out after %d failures", cfg.IPThreshold)
+	}
+	if ok, err := l.unlock(ctx, ipLockKey("10.0.0.1")); err != nil || !ok {
+		t.Fatalf("unlock() = %v, %v, want true", ok, err)
+	}
+	if locked("dave", "10.0.0.1") {
+		t.Fatalf("ip 10.0.0.1 is still locked out after unlock")
+	}
+}
+
+func TestLoginLimiterBackoff(t *testing.T) {
+	l := newLoginLimiter(lockoutConfig{BaseDelay: time.Minute, MaxDelay: 3 * time.Minute}, store.NewMemoryLockoutStore())
+	for n, want := range []time.Duration{time.Minute, 2 * time.Minute, 3 * time.Minute, 3 * time.Minute} {
+		if got := l.backoff(n); got != want {
+			t.Errorf("backoff(%d) = %v, want %v", n, got, want)
+		}
+	}
+}
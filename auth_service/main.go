@@ -2,125 +2,465 @@ package main
 
 import (
 	"context"
+	"errors"
 	//"go/token"
-	"sync"
 
 	//"crypto/rand"
 	//"encoding/base64"
 	"fmt"
 	"log"
 	"net"
+	"net/http"
+	"os"
+	"strconv"
 	"time"
 
 	"github.com/dgrijalva/jwt-go"
-	"golang.org/x/crypto/bcrypt"
+	"github.com/go-redis/redis/v8"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	authint "fin-trans/auth_interceptor_package"
+	"fin-trans/auth_service/passhash"
 	authpb "fin-trans/auth_service/proto"
+	"fin-trans/auth_service/store"
+	usfl "fin-trans/database_methods_package"
+	models "fin-trans/models_package"
+	notifier "fin-trans/notifier_package"
 )
 
-// Session хранит информацию о сессии пользователя
-type Session struct {
-	Token     string
-	ExpiresAt time.Time
+// authPolicy - кто может вызывать методы AuthService.
+// Вход, регистрация, сброс пароля и проверка токенов открыты,
+// работа со своей учётной записью - любому пользователю, управление пользователями - администраторам.
+var authPolicy = authint.Policy{
+	authpb.AuthService_Register_FullMethodName:             authint.Public,
+	authpb.AuthService_Login_FullMethodName:                authint.Public,
+	authpb.AuthService_VerifySecondFactor_FullMethodName:   authint.Public,
+	authpb.AuthService_ValidateToken_FullMethodName:        authint.Public,
+	authpb.AuthService_RefreshToken_FullMethodName:         authint.Public,
+	authpb.AuthService_GetPublicKeys_FullMethodName:        authint.Public,
+	authpb.AuthService_RequestPasswordReset_FullMethodName: authint.Public,
+	authpb.AuthService_ConfirmPasswordReset_FullMethodName: authint.Public,
+
+	authpb.AuthService_Logout_FullMethodName:              authint.Allow(models.RoleCustomer),
+	authpb.AuthService_ListSessions_FullMethodName:        authint.Allow(models.RoleCustomer),
+	authpb.AuthService_RevokeSession_FullMethodName:       authint.Allow(models.RoleCustomer),
+	authpb.AuthService_RevokeOtherSessions_FullMethodName: authint.Allow(models.RoleCustomer),
+	authpb.AuthService_EnrollTOTP_FullMethodName:          authint.Allow(models.RoleCustomer),
+	authpb.AuthService_ConfirmTOTP_FullMethodName:         authint.Allow(models.RoleCustomer),
+	authpb.AuthService_ChangePassword_FullMethodName:      authint.Allow(models.RoleCustomer),
+
+	authpb.AuthService_UnlockAccount_FullMethodName:   authint.Allow(models.RoleAdmin).OrService(),
+	authpb.AuthService_ListUsers_FullMethodName:       authint.Allow(models.RoleAdmin).OrService(),
+	authpb.AuthService_GetUser_FullMethodName:         authint.Allow(models.RoleOperator).OrService(),
+	authpb.AuthService_SetUserRole_FullMethodName:     authint.Allow(models.RoleAdmin).OrService(),
+	authpb.AuthService_SetUserStatus_FullMethodName:   authint.Allow(models.RoleAdmin).OrService(),
+	authpb.AuthService_ListAuditEvents_FullMethodName: authint.Allow(models.RoleAdmin).OrService(),
+
+	authpb.AuthService_IssueStepUpCode_FullMethodName:  authint.ServiceOnly(),
+	authpb.AuthService_VerifyStepUpCode_FullMethodName: authint.ServiceOnly(),
+
+	authpb.AuthService_CreateServiceAccount_FullMethodName: authint.Allow(models.RoleAdmin).OrService(),
+	authpb.AuthService_ListServiceAccounts_FullMethodName:  authint.Allow(models.RoleAdmin).OrService(),
+	authpb.AuthService_CreateAPIKey_FullMethodName:         authint.Allow(models.RoleAdmin).OrService(),
+	authpb.AuthService_ListAPIKeys_FullMethodName:          authint.Allow(models.RoleAdmin).OrService(),
+	authpb.AuthService_RevokeAPIKey_FullMethodName:         authint.Allow(models.RoleAdmin).OrService(),
 }
 
 // Server реализует методы аутентификации
 type server struct {
 	authpb.UnimplementedAuthServiceServer
-	users    map[string]string // пользователи и их хешированные пароли
-	sessions map[string]*Session
-	mu       sync.Mutex // для защиты map сессий
+	users           store.UserStore // хранилище пользователей и их хешированных паролей
+	refreshTokens   store.RefreshTokenStore
+	twoFactor       store.TwoFactorStore
+	passwordResets  store.PasswordResetStore
+	serviceAccounts store.ServiceAccountStore // сервисные аккаунты и их API-ключи
+	notifier        notifier.Notifier         // доставка токенов сброса и уведомлений
+	passwordPolicy  passwordPolicy
+	passwordHash    passhash.Params // параметры хеширования новых паролей
+	tokens          tokenConfig
+	totpCfg         twoFactorConfig
+	limiter         *loginLimiter // счётчики неудачных входов
+	keys            *keyRing      // ключи подписи JWT
+	sessions        store.SessionStore
+	auditLog        store.AuditStore // журнал событий аутентификации, только дозапись
 }
 
-var secretKey = []byte("supersecretkey")
-
 func (s *server) Register(ctx context.Context, req *authpb.RegisterRequest) (*authpb.RegisterResponse, error) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	if _, exists := s.users[req.Username]; exists {
-		return &authpb.RegisterResponse{Success: false, Message: "User already exists"}, nil
+	if err := s.passwordPolicy.validate(req.Password); err != nil {
+		s.audit(ctx, models.AuditEvent{Event: auditRegister, Username: req.Username, Outcome: models.AuditOutcomeFailure, Detail: err.Error()})
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	hashedPassword, err := passhash.Hash(req.Password, s.passwordHash)
 	if err != nil {
 		return nil, status.Error(codes.Internal, "Could not hash password")
 	}
 
-	s.users[req.Username] = string(hashedPassword)
-	return &authpb.RegisterResponse{Success: true, Message: "User registered successfully"}, nil
+	user, err := s.users.CreateUser(ctx, req.Username, hashedPassword)
+	if errors.Is(err, store.ErrUserExists) {
+		s.audit(ctx, models.AuditEvent{Event: auditRegister, Username: req.Username, Outcome: models.AuditOutcomeFailure, Detail: "user already exists"})
+		return &authpb.RegisterResponse{Success: false, Message: "User already exists"}, nil
+	}
+	if err != nil {
+		log.Printf("Ошибка при сохранении пользователя: %v", err)
+		return nil, status.Error(codes.Internal, "Could not save user")
+	}
+
+	s.audit(ctx, models.AuditEvent{Event: auditRegister, UserID: user.ID, Username: user.Username, Outcome: models.AuditOutcomeSuccess})
+	return &authpb.RegisterResponse{Success: true, Message: "User registered successfully", UserId: user.ID}, nil
 }
 
 func (s *server) Login(ctx context.Context, req *authpb.LoginRequest) (*authpb.LoginResponse, error) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+	// Заблокированные имя или IP отсекаем до обращения к БД и хешированию
+	client := clientInfoFromContext(ctx)
+	if err := s.limiter.check(ctx, req.Username, client.IP); err != nil {
+		s.audit(ctx, models.AuditEvent{Event: auditLogin, Username: req.Username, Outcome: models.AuditOutcomeLocked})
+		return nil, err
+	}
 
-	hashedPassword, exists := s.users[req.Username]
-	if !exists || bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(req.Password)) != nil {
-		return &authpb.LoginResponse{Success: false, Token: ""}, nil
+	user, err := s.users.GetUserByUsername(ctx, req.Username)
+	if err != nil && !errors.Is(err, store.ErrUserNotFound) {
+		log.Printf("Ошибка при получении пользователя: %v", err)
+		return nil, status.Error(codes.Internal, "Could not load user")
 	}
+	// Несуществующие имена тоже считаются, чтобы блокировка не выдавала, есть ли пользователь.
+	if reason := checkCredentials(user, req.Password); reason != "" {
+		s.limiter.fail(ctx, req.Username, client.IP)
+		event := models.AuditEvent{Event: auditLogin, Username: req.Username, Outcome: models.AuditOutcomeFailure, Detail: reason}
+		if user != nil {
+			event.UserID = user.ID
+		}
+		s.audit(ctx, event)
+		return &authpb.LoginResponse{Success: false}, nil
+	}
+	s.rehashPassword(ctx, user, req.Password)
 
-	token, err := generateToken(req.Username)
+	// При включённой 2FA токены выдаёт VerifySecondFactor
+	twoFactor, err := s.twoFactorEnabled(ctx, user.ID)
+	if err != nil {
+		log.Printf("Ошибка при получении настроек 2FA: %v", err)
+		return nil, status.Error(codes.Internal, "Could not load two-factor settings")
+	}
+	if twoFactor {
+		challengeID, err := s.newLoginChallenge(ctx, user, req.DeviceName)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "Could not generate challenge")
+		}
+		s.audit(ctx, models.AuditEvent{Event: auditLogin, UserID: user.ID, Username: user.Username, Outcome: models.AuditOutcomeChallenge})
+		return &authpb.LoginResponse{Success: false, SecondFactorRequired: true, ChallengeId: challengeID}, nil
+	}
+	// С 2FA счётчик сбрасывается только после второго фактора, иначе код можно
+	// было бы подбирать, запрашивая новые challenge с известным паролем
+	s.limiter.success(ctx, req.Username)
+
+	// Каждый логин открывает новую сессию и новое семейство refresh-токенов
+	sessionID, err := randomToken()
 	if err != nil {
 		return nil, status.Error(codes.Internal, "Could not generate token")
 	}
 
-	// Создание и сохранение сессии
-	session := &Session{
-		Token:     token,
-		ExpiresAt: time.Now().Add(time.Minute * 1), // Время истечения токена 1 час
+	// Создание токенов и сессии
+	tokens, err := s.issueTokens(ctx, user, sessionID, req.DeviceName)
+	if err != nil {
+		log.Printf("Ошибка при выпуске токенов: %v", err)
+		return nil, status.Error(codes.Internal, "Could not generate token")
+	}
+
+	s.audit(ctx, models.AuditEvent{Event: auditLogin, UserID: user.ID, Username: user.Username, Outcome: models.AuditOutcomeSuccess, Detail: "session " + sessionID})
+	return &authpb.LoginResponse{
+		Success:      true,
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    tokens.ExpiresIn,
+	}, nil
+}
+
+// checkCredentials возвращает причину отказа для журнала аудита или пустую строку
+func checkCredentials(user *models.User, password string) string {
+	switch {
+	case user == nil:
+		return "unknown user"
+	case user.Status != models.UserStatusActive:
+		return "user " + user.Status
 	}
-	s.sessions[req.Username] = session
+	ok, err := passhash.Verify(password, user.PasswordHash)
+	if err != nil {
+		log.Printf("Ошибка при проверке хеша пароля пользователя %d: %v", user.ID, err)
+	}
+	if !ok {
+		return "invalid password"
+	}
+	return ""
+}
 
-	return &authpb.LoginResponse{Success: true, Token: token}, nil
+// rehashPassword пересчитывает хеш, сделанный устаревшим алгоритмом или параметрами.
+// Пароль уже проверен, поэтому ошибка только логируется и не мешает входу.
+func (s *server) rehashPassword(ctx context.Context, user *models.User, password string) {
+	if !passhash.NeedsRehash(user.PasswordHash, s.passwordHash) {
+		return
+	}
+	hashedPassword, err := passhash.Hash(password, s.passwordHash)
+	if err == nil {
+		err = s.users.UpdatePassword(ctx, user.ID, hashedPassword)
+	}
+	if err != nil {
+		log.Printf("Ошибка при обновлении хеша пароля пользователя %d: %v", user.ID, err)
+		return
+	}
+	user.PasswordHash = hashedPassword
 }
 
+// Logout завершает сессию, которой принадлежит токен вызывающего
 func (s *server) Logout(ctx context.Context, req *authpb.LogoutRequest) (*authpb.LogoutResponse, error) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+	caller, err := authint.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-	delete(s.sessions, req.Username)
+	if err := s.revokeSession(ctx, caller.SessionID); err != nil {
+		log.Printf("Ошибка при отзыве сессии: %v", err)
+		return nil, status.Error(codes.Internal, "Could not revoke tokens")
+	}
+	s.auditCaller(ctx, auditLogout, models.AuditOutcomeSuccess, "session "+caller.SessionID)
 	return &authpb.LogoutResponse{Success: true, Message: "Logged out successfully"}, nil
 }
 
+// ValidateToken проверяет access-токен пользователя или API-ключ сервисного аккаунта
 func (s *server) ValidateToken(ctx context.Context, req *authpb.ValidateTokenRequest) (*authpb.ValidateTokenResponse, error) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	session, exists := s.sessions[req.Username]
-	if !exists || session.Token != req.Token || session.ExpiresAt.Before(time.Now()) {
+	id, err := s.validateToken(ctx, req.Token)
+	if err != nil {
+		return &authpb.ValidateTokenResponse{Valid: false}, nil
+	}
+	// Имя пользователя в запросе необязательно, оно есть в самом токене
+	if req.Username != "" && req.Username != id.Username {
 		return &authpb.ValidateTokenResponse{Valid: false}, nil
 	}
 
-	return &authpb.ValidateTokenResponse{Valid: true}, nil
+	return &authpb.ValidateTokenResponse{
+		Valid:            true,
+		UserId:           id.UserID,
+		Username:         id.Username,
+		SessionId:        id.SessionID,
+		Role:             id.Role,
+		ServiceAccountId: id.ServiceAccountID,
+		Scopes:           id.Scopes,
+	}, nil
 }
 
-func generateToken(username string) (string, error) {
-	claims := jwt.MapClaims{
-		"username": username,
-		"exp":      time.Now().Add(time.Minute * 1).Unix(), // Время истечения токена 1 час
+// GetPublicKeys публикует ключи проверки подписи, чтобы другие сервисы проверяли токены сами
+func (s *server) GetPublicKeys(ctx context.Context, req *authpb.GetPublicKeysRequest) (*authpb.GetPublicKeysResponse, error) {
+	keys, err := s.keys.publicKeys()
+	if err != nil {
+		log.Printf("Ошибка при кодировании публичных ключей: %v", err)
+		return nil, status.Error(codes.Internal, "Could not encode public keys")
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(secretKey)
+	resp := &authpb.GetPublicKeysResponse{}
+	for _, k := range keys {
+		resp.Keys = append(resp.Keys, &authpb.JsonWebKey{
+			Kid: k.Kid,
+			Kty: k.Kty,
+			Alg: k.Alg,
+			Use: k.Use,
+			N:   k.N,
+			E:   k.E,
+			Crv: k.Crv,
+			X:   k.X,
+		})
+	}
+	return resp, nil
+}
+
+func (s *server) generateToken(user *models.User, sessionID string, ttl time.Duration) (string, error) {
+	// jti делает токены уникальными, даже если выданы в одну секунду
+	tokenID, err := randomToken()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	claims := &authint.Claims{
+		UserID:    user.ID,
+		Username:  user.Username,
+		SessionID: sessionID,
+		Role:      user.Role,
+		StandardClaims: jwt.StandardClaims{
+			Id:        tokenID,
+			Subject:   strconv.Itoa(int(user.ID)),
+			IssuedAt:  now.Unix(),
+			ExpiresAt: now.Add(ttl).Unix(),
+		},
+	}
+
+	return s.keys.sign(claims)
+}
+
+// startRESTServer поднимает grpc-gateway: вход и регистрация по /v1/auth/...,
+// JWKS доступен по GET /.well-known/jwks.json
+func startRESTServer(cookies tokenCookies) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var muxOpts []runtime.ServeMuxOption
+	if cookies.cfg.Enabled {
+		muxOpts = cookies.muxOptions()
+	}
+	mux := runtime.NewServeMux(muxOpts...)
+	opts := []grpc.DialOption{grpc.WithInsecure()}
+	err := authpb.RegisterAuthServiceHandlerFromEndpoint(ctx, mux, "localhost:50054", opts)
+	if err != nil {
+		log.Fatalf("Failed to register gRPC gateway: %v", err)
+	}
+
+	var handler http.Handler = mux
+	if cookies.cfg.Enabled {
+		handler = cookies.handler(mux)
+	}
+
+	log.Println("HTTP сервер запущен на порту: 8081")
+	if err := http.ListenAndServe(":8081", handler); err != nil {
+		log.Fatalf("Не удалось запустить HTTP сервер: %v", err)
+	}
 }
 
 func main() {
-	lis, err := net.Listen("tcp", ":50051")
+	// Подключение к БД
+	connPostgres := &usfl.ConnPostgres{
+		Host:     "localhost",
+		User:     "postgres",
+		Password: "workout+5",
+		DbName:   "fintrans_transactions_postgres",
+		Port:     "5432",
+		SslMode:  "disable",
+	}
+
+	var users store.UserStore
+	var refreshTokens store.RefreshTokenStore
+	var twoFactor store.TwoFactorStore
+	var passwordResets store.PasswordResetStore
+	var auditLog store.AuditStore
+	var serviceAccounts store.ServiceAccountStore
+	if err := connPostgres.DbConnector(); err != nil {
+		// Без БД пользователи живут только до перезапуска - годится лишь для локальной разработки
+		log.Printf("Error connecting to the database: %v. Using in-memory stores", err)
+		users = store.NewMemoryUserStore()
+		refreshTokens = store.NewMemoryRefreshTokenStore()
+		twoFactor = store.NewMemoryTwoFactorStore()
+		passwordResets = store.NewMemoryPasswordResetStore()
+		auditLog = store.NewMemoryAuditStore()
+		serviceAccounts = store.NewMemoryServiceAccountStore()
+	} else {
+		fmt.Println("Successfully connected to the database!")
+		pgUsers, err := store.NewPostgresUserStore(usfl.DB)
+		if err != nil {
+			log.Fatalf("failed to init user store: %v", err)
+		}
+		users = pgUsers
+		pgRefreshTokens, err := store.NewPostgresRefreshTokenStore(usfl.DB)
+		if err != nil {
+			log.Fatalf("failed to init refresh token store: %v", err)
+		}
+		refreshTokens = pgRefreshTokens
+		pgTwoFactor, err := store.NewPostgresTwoFactorStore(usfl.DB)
+		if err != nil {
+			log.Fatalf("failed to init two-factor store: %v", err)
+		}
+		twoFactor = pgTwoFactor
+		pgPasswordResets, err := store.NewPostgresPasswordResetStore(usfl.DB)
+		if err != nil {
+			log.Fatalf("failed to init password reset store: %v", err)
+		}
+		passwordResets = pgPasswordResets
+		pgAuditLog, err := store.NewPostgresAuditStore(usfl.DB)
+		if err != nil {
+			log.Fatalf("failed to init audit store: %v", err)
+		}
+		auditLog = pgAuditLog
+		pgServiceAccounts, err := store.NewPostgresServiceAccountStore(usfl.DB)
+		if err != nil {
+			log.Fatalf("failed to init service account store: %v", err)
+		}
+		serviceAccounts = pgServiceAccounts
+	}
+
+	// Сессии и счётчики неудачных входов живут в Redis, чтобы все реплики одинаково
+	// проверяли токены и вместе блокировали перебор
+	var sessions store.SessionStore
+	var lockouts store.LockoutStore
+	sharedSessions := false
+	redisCfg := loadRedisConfig()
+	rdb := redis.NewClient(&redis.Options{Addr: redisCfg.Addr, Password: redisCfg.Password})
+	if err := rdb.Ping(context.Background()).Err(); err != nil {
+		log.Printf("Error connecting to Redis at %s: %v. Using in-memory sessions, do not run more than one replica", redisCfg.Addr, err)
+		sessions = store.NewMemorySessionStore()
+		lockouts = store.NewMemoryLockoutStore()
+	} else {
+		fmt.Println("Successfully connected to Redis!")
+		sessions = store.NewRedisSessionStore(rdb)
+		lockouts = store.NewRedisLockoutStore(rdb)
+		sharedSessions = true
+	}
+
+	tokens := loadTokenConfig()
+	keyCfg := loadKeyConfig()
+	// Без общего каталога каждая реплика подписывает токены своими ключами,
+	// и токены одной реплики не принимаются другими
+	if sharedSessions && keyCfg.Dir == "" {
+		log.Fatalf("AUTH_KEYS_DIR must be set to a directory shared by all replicas when sessions are stored in Redis")
+	}
+	keys, err := newKeyRing(keyCfg, tokens.AccessTokenTTL)
+	if err != nil {
+		log.Fatalf("failed to init signing keys: %v", err)
+	}
+	go keys.run(keyCfg)
+
+	passwordHash := loadPasswordHashParams()
+	if err := passwordHash.Validate(); err != nil {
+		log.Fatalf("invalid password hash settings: %v", err)
+	}
+
+	bootstrapAdmins(context.Background(), users, adminUsernames())
+
+	// :50051 занят CardService
+	lis, err := net.Listen("tcp", ":50054")
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
-	s := grpc.NewServer()
 	authService := &server{
-		users:    make(map[string]string),
-		sessions: make(map[string]*Session),
+		users:           users,
+		refreshTokens:   refreshTokens,
+		twoFactor:       twoFactor,
+		passwordResets:  passwordResets,
+		serviceAccounts: serviceAccounts,
+		notifier:        notifier.FromEnv(),
+		passwordPolicy:  loadPasswordPolicy(),
+		passwordHash:    passwordHash,
+		tokens:          tokens,
+		totpCfg:         loadTwoFactorConfig(),
+		limiter:         newLoginLimiter(loadLockoutConfig(), lockouts),
+		keys:            keys,
+		sessions:        sessions,
+		auditLog:        auditLog,
 	}
+
+	// Права на методы задаёт authPolicy
+	validator := sessionValidator{s: authService}
+	authOpts := []authint.Option{authint.WithServiceToken(os.Getenv(authint.ServiceTokenEnv))}
+	s := grpc.NewServer(
+		grpc.UnaryInterceptor(authint.UnaryServerInterceptor(validator, authPolicy, authOpts...)),
+		grpc.StreamInterceptor(authint.StreamServerInterceptor(validator, authPolicy, authOpts...)),
+	)
 	authpb.RegisterAuthServiceServer(s, authService)
+	if err := authPolicy.Check(s); err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	go startRESTServer(tokenCookies{cfg: loadCookieConfig(), refreshTTL: tokens.RefreshTokenTTL})
 
-	fmt.Println("Listening on port :50051")
+	fmt.Println("Listening on port :50054")
 	if err := s.Serve(lis); err != nil {
 		log.Fatalf("failed to serve: %v", err)
 	}
@@ -0,0 +1,175 @@
+// Package passhash хеширует пароли в самоописывающем формате PHC:
+// "$argon2id$v=19$m=65536,t=3,p=2$<соль>$<хеш>" или стандартная строка bcrypt ("$2a$10$...").
+// Алгоритм и параметры хранятся в самом хеше, поэтому их можно менять,
+// не ломая уже сохранённые пароли: NeedsRehash подсказывает, когда пересчитать хеш.
+package passhash
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Алгоритмы хеширования
+const (
+	Argon2id = "argon2id"
+	Bcrypt   = "bcrypt"
+)
+
+var (
+	ErrUnknownAlgorithm = errors.New("неизвестный алгоритм хеширования пароля")
+	ErrMalformedHash    = errors.New("некорректный формат хеша пароля")
+)
+
+// Params - алгоритм и параметры для новых хешей
+type Params struct {
+	Algorithm string
+
+	BcryptCost int
+
+	Argon2Time    uint32 // число проходов
+	Argon2Memory  uint32 // память в КиБ
+	Argon2Threads uint8
+	Argon2SaltLen uint32
+	Argon2KeyLen  uint32
+}
+
+// Validate проверяет, что параметрами можно хешировать
+func (p Params) Validate() error {
+	switch p.Algorithm {
+	case Argon2id:
+		if p.Argon2Time == 0 || p.Argon2Memory == 0 || p.Argon2Threads == 0 || p.Argon2SaltLen == 0 || p.Argon2KeyLen == 0 {
+			return errors.New("параметры argon2id должны быть положительными")
+		}
+	case Bcrypt:
+		if p.BcryptCost < bcrypt.MinCost || p.BcryptCost > bcrypt.MaxCost {
+			return fmt.Errorf("стоимость bcrypt должна быть от %d до %d", bcrypt.MinCost, bcrypt.MaxCost)
+		}
+	default:
+		return ErrUnknownAlgorithm
+	}
+	return nil
+}
+
+// Hash хеширует пароль с параметрами p
+func Hash(password string, p Params) (string, error) {
+	switch p.Algorithm {
+	case Argon2id:
+		salt := make([]byte, p.Argon2SaltLen)
+		if _, err := rand.Read(salt); err != nil {
+			return "", err
+		}
+		key := argon2.IDKey([]byte(password), salt, p.Argon2Time, p.Argon2Memory, p.Argon2Threads, p.Argon2KeyLen)
+		return encodeArgon2(argon2Hash{
+			time:    p.Argon2Time,
+			memory:  p.Argon2Memory,
+			threads: p.Argon2Threads,
+			salt:    salt,
+			key:     key,
+		}), nil
+	case Bcrypt:
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), p.BcryptCost)
+		return string(hash), err
+	default:
+		return "", ErrUnknownAlgorithm
+	}
+}
+
+// Verify сравнивает пароль с хешем любого поддерживаемого формата
+func Verify(password, encoded string) (bool, error) {
+	switch algorithmOf(encoded) {
+	case Argon2id:
+		h, err := decodeArgon2(encoded)
+		if err != nil {
+			return false, err
+		}
+		key := argon2.IDKey([]byte(password), h.salt, h.time, h.memory, h.threads, uint32(len(h.key)))
+		return subtle.ConstantTimeCompare(key, h.key) == 1, nil
+	case Bcrypt:
+		err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, nil
+		}
+		return err == nil, err
+	default:
+		return false, ErrUnknownAlgorithm
+	}
+}
+
+// NeedsRehash - хеш сделан другим алгоритмом или с другими параметрами, чем p
+func NeedsRehash(encoded string, p Params) bool {
+	if algorithmOf(encoded) != p.Algorithm {
+		return true
+	}
+	switch p.Algorithm {
+	case Argon2id:
+		h, err := decodeArgon2(encoded)
+		return err != nil || h.time != p.Argon2Time || h.memory != p.Argon2Memory ||
+			h.threads != p.Argon2Threads || uint32(len(h.salt)) != p.Argon2SaltLen || uint32(len(h.key)) != p.Argon2KeyLen
+	case Bcrypt:
+		cost, err := bcrypt.Cost([]byte(encoded))
+		return err != nil || cost != p.BcryptCost
+	}
+	return true
+}
+
+func algorithmOf(encoded string) string {
+	switch {
+	case strings.HasPrefix(encoded, "$argon2id$"):
+		return Argon2id
+	case strings.HasPrefix(encoded, "$2a$"), strings.HasPrefix(encoded, "$2b$"), strings.HasPrefix(encoded, "$2y$"):
+		return Bcrypt
+	}
+	return ""
+}
+
+type argon2Hash struct {
+	time    uint32
+	memory  uint32
+	threads uint8
+	salt    []byte
+	key     []byte
+}
+
+var b64 = base64.RawStdEncoding
+
+func encodeArgon2(h argon2Hash) string {
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, h.memory, h.time, h.threads, b64.EncodeToString(h.salt), b64.EncodeToString(h.key))
+}
+
+func decodeArgon2(encoded string) (argon2Hash, error) {
+	var h argon2Hash
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != Argon2id {
+		return h, ErrMalformedHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return h, ErrMalformedHash
+	}
+	if version != argon2.Version {
+		return h, fmt.Errorf("неподдерживаемая версия argon2: %d", version)
+	}
+	// Нулевые параметры не пропускаем: argon2.IDKey паникует при p=0
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &h.memory, &h.time, &h.threads); err != nil ||
+		h.memory == 0 || h.time == 0 || h.threads == 0 {
+		return h, ErrMalformedHash
+	}
+
+	var err error
+	if h.salt, err = b64.DecodeString(parts[4]); err != nil {
+		return h, ErrMalformedHash
+	}
+	if h.key, err = b64.DecodeString(parts[5]); err != nil || len(h.key) == 0 {
+		return h, ErrMalformedHash
+	}
+	return h, nil
+}
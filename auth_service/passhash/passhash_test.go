@@ -0,0 +1,206 @@
+package passhash
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Маленькие параметры, чтобы тесты шли быстро
+var (
+	testArgon2 = Params{Algorithm: Argon2id, Argon2Time: 1, Argon2Memory: 64, Argon2Threads: 1, Argon2SaltLen: 16, Argon2KeyLen: 32}
+	testBcrypt = Params{Algorithm: Bcrypt, BcryptCost: bcrypt.MinCost}
+)
+
+func TestVerifyKnownHashes(t *testing.T) {
+	tests := []struct {
+		name, password, encoded string
+		want                    bool
+	}{
+		// Эталонная утилита argon2: echo -n password | argon2 somesalt -id -t 2 -m 16 -p 1
+		{"argon2id reference", "password", "$argon2id$v=19$m=65536,t=2,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc", true},
+		{"argon2id wrong password", "Password", "$argon2id$v=19$m=65536,t=2,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc", false},
+		// Тестовые векторы OpenBSD bcrypt
+		{"bcrypt reference", "U*U", "$2a$05$CCCCCCCCCCCCCCCCCCCCC.E5YPO9kmyuRGyh0XouQYb4YMJKvyOeW", true},
+		{"bcrypt empty password", "", "$2a$05$CCCCCCCCCCCCCCCCCCCCC.7uG0VCzI2bS7j6ymqJi9CdcdxiRTWNy", true},
+		{"bcrypt wrong password", "U*V", "$2a$05$CCCCCCCCCCCCCCCCCCCCC.E5YPO9kmyuRGyh0XouQYb4YMJKvyOeW", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, err := Verify(tt.password, tt.encoded)
+			if err != nil || ok != tt.want {
+				t.Fatalf("Verify() = %v, %v, want %v", ok, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestHashVerifyRoundTrip(t *testing.T) {
+	for _, p := range []Params{testArgon2, testBcrypt} {
+		t.Run(p.Algorithm, func(t *testing.T) {
+			for _, password := range []string{"correct horse battery staple", "", "пароль с юникодом"} {
+				encoded, err := Hash(password, p)
+				if err != nil {
+					t.Fatalf("Hash(%q): %v", password, err)
+				}
+				if ok, err := Verify(password, encoded); err != nil || !ok {
+					t.Fatalf("Verify(%q) = %v, %v, want true", password, ok, err)
+				}
+				if ok, err := Verify(password+"x", encoded); err != nil || ok {
+					t.Fatalf("Verify(%q) with wrong password = %v, %v, want false", password, ok, err)
+				}
+				if NeedsRehash(encoded, p) {
+					t.Fatalf("NeedsRehash(%q) = true for hash made with the same params", encoded)
+				}
+			}
+			// Соль случайная: одинаковые пароли дают разные хеши
+			a, _ := Hash("secret", p)
+			b, _ := Hash("secret", p)
+			if a == b {
+				t.Fatalf("Hash() returned the same hash twice: %q", a)
+			}
+		})
+	}
+}
+
+func TestNeedsRehash(t *testing.T) {
+	argon2Hash, err := Hash("secret", testArgon2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bcryptHash, err := Hash("secret", testBcrypt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	with := func(p Params, change func(*Params)) Params {
+		change(&p)
+		return p
+	}
+	tests := []struct {
+		name    string
+		encoded string
+		p       Params
+		want    bool
+	}{
+		{"argon2id same", argon2Hash, testArgon2, false},
+		{"argon2id time", argon2Hash, with(testArgon2, func(p *Params) { p.Argon2Time = 2 }), true},
+		{"argon2id memory", argon2Hash, with(testArgon2, func(p *Params) { p.Argon2Memory = 128 }), true},
+		{"argon2id threads", argon2Hash, with(testArgon2, func(p *Params) { p.Argon2Threads = 2 }), true},
+		{"argon2id salt length", argon2Hash, with(testArgon2, func(p *Params) { p.Argon2SaltLen = 32 }), true},
+		{"argon2id key length", argon2Hash, with(testArgon2, func(p *Params) { p.Argon2KeyLen = 64 }), true},
+		{"argon2id to bcrypt", argon2Hash, testBcrypt, true},
+		{"bcrypt same", bcryptHash, testBcrypt, false},
+		{"bcrypt cost", bcryptHash, with(testBcrypt, func(p *Params) { p.BcryptCost = bcrypt.MinCost + 1 }), true},
+		{"bcrypt to argon2id", bcryptHash, testArgon2, true},
+		{"malformed", "$argon2id$v=19$m=64", testArgon2, true},
+		{"unknown", "plaintext", testBcrypt, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NeedsRehash(tt.encoded, tt.p); got != tt.want {
+				t.Fatalf("NeedsRehash() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// Вход со старым хешем: пароль проверяется по старым параметрам, после чего хеш
+// пересчитывается с новыми и больше не требует пересчёта
+func TestVerifyAndRehash(t *testing.T) {
+	upgrades := []struct {
+		name     string
+		from, to Params
+	}{
+		{"bcrypt to argon2id", testBcrypt, testArgon2},
+		{"bcrypt cost", testBcrypt, Params{Algorithm: Bcrypt, BcryptCost: bcrypt.MinCost + 1}},
+		{"argon2id params", testArgon2, Params{Algorithm: Argon2id, Argon2Time: 2, Argon2Memory: 128, Argon2Threads: 2, Argon2SaltLen: 16, Argon2KeyLen: 32}},
+		{"argon2id to bcrypt", testArgon2, testBcrypt},
+	}
+	for _, tt := range upgrades {
+		t.Run(tt.name, func(t *testing.T) {
+			old, err := Hash("secret", tt.from)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok, err := Verify("secret", old); err != nil || !ok {
+				t.Fatalf("Verify(old) = %v, %v, want true", ok, err)
+			}
+			if !NeedsRehash(old, tt.to) {
+				t.Fatalf("NeedsRehash(old) = false, want true")
+			}
+			upgraded, err := Hash("secret", tt.to)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok, err := Verify("secret", upgraded); err != nil || !ok {
+				t.Fatalf("Verify(upgraded) = %v, %v, want true", ok, err)
+			}
+			if NeedsRehash(upgraded, tt.to) {
+				t.Fatalf("NeedsRehash(upgraded) = true, want false")
+			}
+		})
+	}
+}
+
+func TestVerifyMalformed(t *testing.T) {
+	tests := []struct {
+		name    string
+		encoded string
+		want    error
+	}{
+		{"empty", "", ErrUnknownAlgorithm},
+		{"plaintext", "secret", ErrUnknownAlgorithm},
+		{"argon2i", "$argon2i$v=19$m=64,t=1,p=1$c29tZXNhbHQ$c29tZWtleQ", ErrUnknownAlgorithm},
+		{"too few parts", "$argon2id$v=19$m=64,t=1,p=1$c29tZXNhbHQ", ErrMalformedHash},
+		{"bad version", "$argon2id$v=x$m=64,t=1,p=1$c29tZXNhbHQ$c29tZWtleQ", ErrMalformedHash},
+		{"bad params", "$argon2id$v=19$m=64$c29tZXNhbHQ$c29tZWtleQ", ErrMalformedHash},
+		{"zero threads", "$argon2id$v=19$m=64,t=1,p=0$c29tZXNhbHQ$c29tZWtleQ", ErrMalformedHash},
+		{"zero time", "$argon2id$v=19$m=64,t=0,p=1$c29tZXNhbHQ$c29tZWtleQ", ErrMalformedHash},
+		{"zero memory", "$argon2id$v=19$m=0,t=1,p=1$c29tZXNhbHQ$c29tZWtleQ", ErrMalformedHash},
+		{"bad salt", "$argon2id$v=19$m=64,t=1,p=1$!!!$c29tZWtleQ", ErrMalformedHash},
+		{"empty key", "$argon2id$v=19$m=64,t=1,p=1$c29tZXNhbHQ$", ErrMalformedHash},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, err := Verify("secret", tt.encoded)
+			if ok || !errors.Is(err, tt.want) {
+				t.Fatalf("Verify() = %v, %v, want %v", ok, err, tt.want)
+			}
+		})
+	}
+
+	ok, err := Verify("secret", "$argon2id$v=16$m=64,t=1,p=1$c29tZXNhbHQ$c29tZWtleQ")
+	if ok || err == nil || !strings.Contains(err.Error(), "версия") {
+		t.Fatalf("Verify() with argon2 v=16 = %v, %v, want unsupported version error", ok, err)
+	}
+	if ok, err := Verify("secret", "$2a$04$short"); ok || err == nil {
+		t.Fatalf("Verify() with truncated bcrypt hash = %v, %v, want error", ok, err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		p     Params
+		valid bool
+	}{
+		{"argon2id", testArgon2, true},
+		{"bcrypt", testBcrypt, true},
+		{"argon2id zero memory", Params{Algorithm: Argon2id, Argon2Time: 1, Argon2Threads: 1, Argon2SaltLen: 16, Argon2KeyLen: 32}, false},
+		{"bcrypt cost too low", Params{Algorithm: Bcrypt, BcryptCost: bcrypt.MinCost - 1}, false},
+		{"bcrypt cost too high", Params{Algorithm: Bcrypt, BcryptCost: bcrypt.MaxCost + 1}, false},
+		{"unknown algorithm", Params{Algorithm: "scrypt"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.p.Validate(); (err == nil) != tt.valid {
+				t.Fatalf("Validate() = %v, want valid %v", err, tt.valid)
+			}
+		})
+	}
+	if _, err := Hash("secret", Params{Algorithm: "scrypt"}); !errors.Is(err, ErrUnknownAlgorithm) {
+		t.Fatalf("Hash() with unknown algorithm = %v, want ErrUnknownAlgorithm", err)
+	}
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+	"unicode"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	authint "fin-trans/auth_interceptor_package"
+	"fin-trans/auth_service/passhash"
+	authpb "fin-trans/auth_service/proto"
+	"fin-trans/auth_service/store"
+	models "fin-trans/models_package"
+	notifier "fin-trans/notifier_package"
+)
+
+// bcrypt учитывает только первые 72 байта пароля; ограничение действует для всех
+// алгоритмов, чтобы смена AUTH_PASSWORD_HASH не делала старые пароли недопустимыми
+const maxPasswordBytes = 72
+
+// validate возвращает ошибку с описанием первого нарушенного требования
+func (p passwordPolicy) validate(password string) error {
+	if len([]rune(password)) < p.MinLength {
+		return fmt.Errorf("password must be at least %d characters long", p.MinLength)
+	}
+	if len(password) > maxPasswordBytes {
+		return fmt.Errorf("password must be at most %d bytes long", maxPasswordBytes)
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			symbol = true
+		}
+	}
+	switch {
+	case p.RequireUpper && !upper:
+		return errors.New("password must contain an uppercase letter")
+	case p.RequireLower && !lower:
+		return errors.New("password must contain a lowercase letter")
+	case p.RequireDigit && !digit:
+		return errors.New("password must contain a digit")
+	case p.RequireSymbol && !symbol:
+		return errors.New("password must contain a symbol")
+	}
+	return nil
+}
+
+// ChangePassword меняет пароль по текущему и завершает все остальные сессии пользователя
+func (s *server) ChangePassword(ctx context.Context, req *authpb.ChangePasswordRequest) (*authpb.ChangePasswordResponse, error) {
+	caller, err := authint.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Подбор текущего пароля с украденным токеном ограничивается так же, как вход
+	client := clientInfoFromContext(ctx)
+	if err := s.limiter.check(ctx, caller.Username, client.IP); err != nil {
+		s.auditCaller(ctx, auditPasswordChange, models.AuditOutcomeLocked, "")
+		return nil, err
+	}
+
+	user, err := s.users.GetUserByID(ctx, caller.UserID)
+	if err != nil {
+		log.Printf("Ошибка при получении пользователя: %v", err)
+		return nil, status.Error(codes.Internal, "Could not load user")
+	}
+	if ok, _ := passhash.Verify(req.CurrentPassword, user.PasswordHash); !ok {
+		s.limiter.fail(ctx, caller.Username, client.IP)
+		s.auditCaller(ctx, auditPasswordChange, models.AuditOutcomeFailure, "invalid current password")
+		return &authpb.ChangePasswordResponse{Success: false, Message: "Current password is incorrect"}, nil
+	}
+	if err := s.passwordPolicy.validate(req.NewPassword); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := s.setPassword(ctx, user.ID, req.NewPassword); err != nil {
+		return nil, err
+	}
+	revoked, err := s.revokeUserSessions(ctx, user.ID, caller.SessionID)
+	if err != nil {
+		log.Printf("Ошибка при отзыве сессий пользователя %d: %v", user.ID, err)
+		return nil, status.Error(codes.Internal, "Could not revoke sessions")
+	}
+
+	s.auditCaller(ctx, auditPasswordChange, models.AuditOutcomeSuccess, fmt.Sprintf("revoked %d sessions", revoked))
+	s.notify(ctx, user, "Password changed", "Your password was changed. If it wasn't you, reset your password immediately.")
+	return &authpb.ChangePasswordResponse{Success: true, Message: "Password changed", RevokedSessions: revoked}, nil
+}
+
+// RequestPasswordReset отправляет пользователю одноразовый токен сброса.
+// Ответ одинаков для существующих и несуществующих имён.
+func (s *server) RequestPasswordReset(ctx context.Context, req *authpb.RequestPasswordResetRequest) (*authpb.RequestPasswordResetResponse, error) {
+	resp := &authpb.RequestPasswordResetResponse{Success: true, Message: "If the account exists, reset instructions have been sent"}
+
+	user, err := s.users.GetUserByUsername(ctx, req.Username)
+	if errors.Is(err, store.ErrUserNotFound) {
+		s.audit(ctx, models.AuditEvent{Event: auditPasswordResetRequest, Username: req.Username, Outcome: models.AuditOutcomeFailure, Detail: "unknown user"})
+		return resp, nil
+	}
+	if err != nil {
+		log.Printf("Ошибка при получении пользователя: %v", err)
+		return nil, status.Error(codes.Internal, "Could not load user")
+	}
+	if user.Status != models.UserStatusActive {
+		s.audit(ctx, models.AuditEvent{Event: auditPasswordResetRequest, UserID: user.ID, Username: user.Username, Outcome: models.AuditOutcomeFailure, Detail: "user " + user.Status})
+		return resp, nil
+	}
+
+	token, err := randomToken()
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Could not generate token")
+	}
+	now := time.Now()
+	err = s.passwordResets.SavePasswordReset(ctx, &models.PasswordResetToken{
+		TokenHash: hashToken(token),
+		UserID:    user.ID,
+		CreatedAt: now,
+		ExpiresAt: now.Add(s.tokens.PasswordResetTTL),
+	})
+	if err != nil {
+		log.Printf("Ошибка при сохранении токена сброса: %v", err)
+		return nil, status.Error(codes.Internal, "Could not save reset token")
+	}
+
+	s.audit(ctx, models.AuditEvent{Event: auditPasswordResetRequest, UserID: user.ID, Username: user.Username, Outcome: models.AuditOutcomeSuccess})
+	s.notify(ctx, user, "Password reset",
+		fmt.Sprintf("Use this token to reset your password: %s\nIt expires in %v.", token, s.tokens.PasswordResetTTL))
+	return resp, nil
+}
+
+// ConfirmPasswordReset устанавливает новый пароль по токену сброса и завершает все сессии пользователя
+func (s *server) ConfirmPasswordReset(ctx context.Context, req *authpb.ConfirmPasswordResetRequest) (*authpb.ConfirmPasswordResetResponse, error) {
+	// Политику проверяем до использования токена, чтобы неудачный пароль его не сжёг
+	if err := s.passwordPolicy.validate(req.NewPassword); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	invalid := &authpb.ConfirmPasswordResetResponse{Success: false, Message: "Invalid or expired token"}
+	reset, err := s.passwordResets.ConsumePasswordReset(ctx, hashToken(req.Token))
+	if errors.Is(err, store.ErrPasswordResetNotFound) {
+		s.audit(ctx, models.AuditEvent{Event: auditPasswordReset, Outcome: models.AuditOutcomeFailure, Detail: "unknown token"})
+		return invalid, nil
+	}
+	if err != nil {
+		log.Printf("Ошибка при использовании токена сброса: %v", err)
+		return nil, status.Error(codes.Internal, "Could not verify token")
+	}
+	if reset.ExpiresAt.Before(time.Now()) {
+		s.audit(ctx, models.AuditEvent{Event: auditPasswordReset, UserID: reset.UserID, Outcome: models.AuditOutcomeFailure, Detail: "expired token"})
+		return invalid, nil
+	}
+
+	user, err := s.users.GetUserByID(ctx, reset.UserID)
+	if err != nil {
+		log.Printf("Ошибка при получении пользователя: %v", err)
+		return nil, status.Error(codes.Internal, "Could not load user")
+	}
+	if user.Status != models.UserStatusActive {
+		s.audit(ctx, models.AuditEvent{Event: auditPasswordReset, UserID: user.ID, Username: user.Username, Outcome: models.AuditOutcomeFailure, Detail: "user " + user.Status})
+		return invalid, nil
+	}
+
+	if err := s.setPassword(ctx, user.ID, req.NewPassword); err != nil {
+		return nil, err
+	}
+	if err := s.passwordResets.InvalidateUserPasswordResets(ctx, user.ID); err != nil {
+		log.Printf("Ошибка при отзыве токенов сброса пользователя %d: %v", user.ID, err)
+	}
+	if _, err := s.revokeUserSessions(ctx, user.ID, ""); err != nil {
+		log.Printf("Ошибка при отзыве сессий пользователя %d: %v", user.ID, err)
+		return nil, status.Error(codes.Internal, "Could not revoke sessions")
+	}
+	// Сброс подтверждает владение учётной записью, блокировку входа снимаем
+	s.limiter.success(ctx, user.Username)
+
+	s.audit(ctx, models.AuditEvent{Event: auditPasswordReset, UserID: user.ID, Username: user.Username, Outcome: models.AuditOutcomeSuccess})
+	s.notify(ctx, user, "Password changed", "Your password was reset and all sessions were signed out.")
+	return &authpb.ConfirmPasswordResetResponse{Success: true, Message: "Password has been reset"}, nil
+}
+
+func (s *server) setPassword(ctx context.Context, userID int32, password string) error {
+	hashedPassword, err := passhash.Hash(password, s.passwordHash)
+	if err != nil {
+		return status.Error(codes.Internal, "Could not hash password")
+	}
+	if err := s.users.UpdatePassword(ctx, userID, hashedPassword); err != nil {
+		log.Printf("Ошибка при сохранении пароля пользователя %d: %v", userID, err)
+		return status.Error(codes.Internal, "Could not save password")
+	}
+	return nil
+}
+
+// notify отправляет уведомление; ошибка доставки не отменяет уже выполненную операцию
+func (s *server) notify(ctx context.Context, user *models.User, subject, body string) {
+	msg := notifier.Message{Recipient: user.Username, Subject: subject, Body: body}
+	if err := s.notifier.Notify(ctx, msg); err != nil {
+		log.Printf("Ошибка при отправке уведомления пользователю %d: %v", user.ID, err)
+	}
+}
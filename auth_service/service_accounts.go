@@ -0,0 +1,217 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	authint "fin-trans/auth_interceptor_package"
+	authpb "fin-trans/auth_service/proto"
+	"fin-trans/auth_service/store"
+	models "fin-trans/models_package"
+)
+
+// API-ключ имеет вид ftk_<id>.<secret>: по id ключ ищется в хранилище,
+// secret сверяется с сохранённым sha256-хешем
+const apiKeyIDBytes = 8
+
+func (s *server) CreateServiceAccount(ctx context.Context, req *authpb.CreateServiceAccountRequest) (*authpb.CreateServiceAccountResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+
+	account, err := s.serviceAccounts.CreateServiceAccount(ctx, name, req.Description)
+	if errors.Is(err, store.ErrServiceAccountExists) {
+		return nil, status.Error(codes.AlreadyExists, "Service account already exists")
+	}
+	if err != nil {
+		log.Printf("Ошибка при создании сервисного аккаунта: %v", err)
+		return nil, status.Error(codes.Internal, "Could not create service account")
+	}
+
+	s.auditCaller(ctx, auditServiceAccountCreate, models.AuditOutcomeSuccess, fmt.Sprintf("service account %d %s", account.ID, account.Name))
+	return &authpb.CreateServiceAccountResponse{ServiceAccount: serviceAccountToProto(account)}, nil
+}
+
+func (s *server) ListServiceAccounts(ctx context.Context, req *authpb.ListServiceAccountsRequest) (*authpb.ListServiceAccountsResponse, error) {
+	accounts, err := s.serviceAccounts.ListServiceAccounts(ctx)
+	if err != nil {
+		log.Printf("Ошибка при получении сервисных аккаунтов: %v", err)
+		return nil, status.Error(codes.Internal, "Could not load service accounts")
+	}
+
+	resp := &authpb.ListServiceAccountsResponse{}
+	for i := range accounts {
+		resp.ServiceAccounts = append(resp.ServiceAccounts, serviceAccountToProto(&accounts[i]))
+	}
+	return resp, nil
+}
+
+// CreateAPIKey выпускает ключ сервисного аккаунта. Ключ возвращается только здесь,
+// в хранилище остаётся его хеш.
+func (s *server) CreateAPIKey(ctx context.Context, req *authpb.CreateAPIKeyRequest) (*authpb.CreateAPIKeyResponse, error) {
+	scopes, err := normalizeScopes(req.Scopes)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	idBytes := make([]byte, apiKeyIDBytes)
+	if _, err := rand.Read(idBytes); err != nil {
+		return nil, status.Error(codes.Internal, "Could not generate key")
+	}
+	secret, err := randomToken()
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Could not generate key")
+	}
+
+	key := &models.APIKey{
+		ID:               hex.EncodeToString(idBytes),
+		ServiceAccountID: req.ServiceAccountId,
+		Name:             req.Name,
+		KeyHash:          hashToken(secret),
+		Scopes:           scopes,
+		CreatedAt:        time.Now(),
+	}
+	err = s.serviceAccounts.CreateAPIKey(ctx, key)
+	if errors.Is(err, store.ErrServiceAccountNotFound) {
+		return nil, status.Error(codes.NotFound, "Service account not found")
+	}
+	if err != nil {
+		log.Printf("Ошибка при сохранении API-ключа: %v", err)
+		return nil, status.Error(codes.Internal, "Could not save key")
+	}
+
+	s.auditCaller(ctx, auditAPIKeyCreate, models.AuditOutcomeSuccess,
+		fmt.Sprintf("key %s of service account %d, scopes %s", key.ID, key.ServiceAccountID, strings.Join(scopes, ",")))
+	return &authpb.CreateAPIKeyResponse{
+		Key:    apiKeyToProto(key),
+		ApiKey: authint.APIKeyPrefix + key.ID + "." + secret,
+	}, nil
+}
+
+func (s *server) ListAPIKeys(ctx context.Context, req *authpb.ListAPIKeysRequest) (*authpb.ListAPIKeysResponse, error) {
+	if _, err := s.serviceAccounts.GetServiceAccount(ctx, req.ServiceAccountId); err != nil {
+		if errors.Is(err, store.ErrServiceAccountNotFound) {
+			return nil, status.Error(codes.NotFound, "Service account not found")
+		}
+		log.Printf("Ошибка при получении сервисного аккаунта: %v", err)
+		return nil, status.Error(codes.Internal, "Could not load service account")
+	}
+
+	keys, err := s.serviceAccounts.ListAPIKeys(ctx, req.ServiceAccountId)
+	if err != nil {
+		log.Printf("Ошибка при получении API-ключей: %v", err)
+		return nil, status.Error(codes.Internal, "Could not load keys")
+	}
+
+	resp := &authpb.ListAPIKeysResponse{}
+	for i := range keys {
+		resp.Keys = append(resp.Keys, apiKeyToProto(&keys[i]))
+	}
+	return resp, nil
+}
+
+// RevokeAPIKey отзывает ключ сразу: ключи проверяются по хранилищу при каждом вызове
+func (s *server) RevokeAPIKey(ctx context.Context, req *authpb.RevokeAPIKeyRequest) (*authpb.RevokeAPIKeyResponse, error) {
+	err := s.serviceAccounts.RevokeAPIKey(ctx, req.KeyId)
+	if errors.Is(err, store.ErrAPIKeyNotFound) {
+		return &authpb.RevokeAPIKeyResponse{Success: false, Message: "Key not found"}, nil
+	}
+	if err != nil {
+		log.Printf("Ошибка при отзыве API-ключа %s: %v", req.KeyId, err)
+		return nil, status.Error(codes.Internal, "Could not revoke key")
+	}
+
+	s.auditCaller(ctx, auditAPIKeyRevoke, models.AuditOutcomeSuccess, "key "+req.KeyId)
+	return &authpb.RevokeAPIKeyResponse{Success: true, Message: "Key revoked"}, nil
+}
+
+// validateAPIKey проверяет API-ключ и возвращает его сервисный аккаунт со scopes ключа
+func (s *server) validateAPIKey(ctx context.Context, token string) (*authint.Identity, error) {
+	id, secret, found := strings.Cut(strings.TrimPrefix(token, authint.APIKeyPrefix), ".")
+	if !found || id == "" || secret == "" {
+		return nil, errInvalidToken
+	}
+
+	key, err := s.serviceAccounts.GetAPIKey(ctx, id)
+	if err != nil {
+		if !errors.Is(err, store.ErrAPIKeyNotFound) {
+			log.Printf("Ошибка при получении API-ключа: %v", err)
+		}
+		return nil, errInvalidToken
+	}
+	if key.RevokedAt != nil || subtle.ConstantTimeCompare([]byte(hashToken(secret)), []byte(key.KeyHash)) != 1 {
+		return nil, errInvalidToken
+	}
+
+	account, err := s.serviceAccounts.GetServiceAccount(ctx, key.ServiceAccountID)
+	if err != nil {
+		log.Printf("Ошибка при получении сервисного аккаунта %d: %v", key.ServiceAccountID, err)
+		return nil, errInvalidToken
+	}
+
+	now := time.Now()
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) > sessionTouchInterval {
+		if err := s.serviceAccounts.TouchAPIKey(ctx, key.ID, now); err != nil {
+			log.Printf("Ошибка при обновлении API-ключа: %v", err)
+		}
+	}
+	return &authint.Identity{Username: account.Name, ServiceAccountID: account.ID, Scopes: key.Scopes}, nil
+}
+
+// normalizeScopes проверяет scopes и убирает повторы
+func normalizeScopes(scopes []string) ([]string, error) {
+	if len(scopes) == 0 {
+		return nil, errors.New("at least one scope is required")
+	}
+	seen := make(map[string]bool, len(scopes))
+	result := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if !authint.ValidScope(scope) {
+			return nil, fmt.Errorf("unknown scope %q", scope)
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			result = append(result, scope)
+		}
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+func serviceAccountToProto(account *models.ServiceAccount) *authpb.ServiceAccount {
+	return &authpb.ServiceAccount{
+		Id:          account.ID,
+		Name:        account.Name,
+		Description: account.Description,
+		CreatedAt:   account.CreatedAt.Unix(),
+	}
+}
+
+func apiKeyToProto(key *models.APIKey) *authpb.APIKeyInfo {
+	info := &authpb.APIKeyInfo{
+		KeyId:            key.ID,
+		ServiceAccountId: key.ServiceAccountID,
+		Name:             key.Name,
+		Scopes:           key.Scopes,
+		CreatedAt:        key.CreatedAt.Unix(),
+	}
+	if key.LastUsedAt != nil {
+		info.LastUsedAt = key.LastUsedAt.Unix()
+	}
+	if key.RevokedAt != nil {
+		info.RevokedAt = key.RevokedAt.Unix()
+	}
+	return info
+}
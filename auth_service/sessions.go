@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	authint "fin-trans/auth_interceptor_package"
+	authpb "fin-trans/auth_service/proto"
+	"fin-trans/auth_service/store"
+	models "fin-trans/models_package"
+)
+
+var errInvalidToken = errors.New("недействительный токен")
+
+// Время последней активности сессии пишется в хранилище не чаще этого интервала
+const sessionTouchInterval = time.Minute
+
+// validateAccessToken проверяет подпись токена и то, что его сессия жива
+// и токен - последний выданный в ней (после ротации старый access-токен не принимается)
+func (s *server) validateAccessToken(ctx context.Context, token string) (*authint.Claims, error) {
+	// Подпись проверяем по связке ключей: токен, подписанный выведенным ключом, ещё валиден
+	claims := &authint.Claims{}
+	if _, err := jwt.ParseWithClaims(token, claims, s.keys.keyFunc); err != nil {
+		return nil, errInvalidToken
+	}
+
+	session, err := s.sessions.GetSession(ctx, claims.SessionID)
+	if err != nil {
+		if !errors.Is(err, store.ErrSessionNotFound) {
+			log.Printf("Ошибка при получении сессии: %v", err)
+		}
+		return nil, errInvalidToken
+	}
+	now := time.Now()
+	if session.Token != token || session.ExpiresAt.Before(now) {
+		return nil, errInvalidToken
+	}
+
+	if now.Sub(session.LastSeenAt) > sessionTouchInterval {
+		if err := s.sessions.TouchSession(ctx, session.ID, now); err != nil {
+			log.Printf("Ошибка при обновлении сессии: %v", err)
+		}
+	}
+	return claims, nil
+}
+
+// sessionValidator проверяет токены для интерсептора самого AuthService
+type sessionValidator struct {
+	s *server
+}
+
+func (v sessionValidator) Validate(ctx context.Context, token string) (*authint.Identity, error) {
+	return v.s.validateToken(ctx, token)
+}
+
+// validateToken - общая проверка для интерсептора и ValidateToken:
+// API-ключи сервисных аккаунтов и access-токены пользователей
+func (s *server) validateToken(ctx context.Context, token string) (*authint.Identity, error) {
+	if authint.IsAPIKey(token) {
+		return s.validateAPIKey(ctx, token)
+	}
+	claims, err := s.validateAccessToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return &authint.Identity{UserID: claims.UserID, Username: claims.Username, SessionID: claims.SessionID, Role: claims.Role}, nil
+}
+
+// revokeSession завершает сессию и отзывает её refresh-токены
+func (s *server) revokeSession(ctx context.Context, sessionID string) error {
+	if err := s.sessions.DeleteSession(ctx, sessionID); err != nil {
+		return err
+	}
+
+	// Без отзыва refresh-токен можно было бы обменять на новый access-токен
+	return s.refreshTokens.RevokeRefreshTokenFamily(ctx, sessionID)
+}
+
+// userSessions возвращает живые сессии пользователя, начиная с самых свежих
+func (s *server) userSessions(ctx context.Context, userID int32) ([]models.Session, error) {
+	sessions, err := s.sessions.ListUserSessions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].LastSeenAt.After(sessions[j].LastSeenAt) })
+	return sessions, nil
+}
+
+func (s *server) ListSessions(ctx context.Context, req *authpb.ListSessionsRequest) (*authpb.ListSessionsResponse, error) {
+	caller, err := authint.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.userSessions(ctx, caller.UserID)
+	if err != nil {
+		log.Printf("Ошибка при получении сессий пользователя %d: %v", caller.UserID, err)
+		return nil, status.Error(codes.Internal, "Could not load sessions")
+	}
+
+	resp := &authpb.ListSessionsResponse{}
+	for _, session := range sessions {
+		resp.Sessions = append(resp.Sessions, &authpb.SessionInfo{
+			SessionId:  session.ID,
+			DeviceName: session.DeviceName,
+			UserAgent:  session.UserAgent,
+			Ip:         session.IP,
+			CreatedAt:  session.CreatedAt.Unix(),
+			LastSeenAt: session.LastSeenAt.Unix(),
+			ExpiresAt:  session.ExpiresAt.Unix(),
+			Current:    session.ID == caller.SessionID,
+		})
+	}
+	return resp, nil
+}
+
+func (s *server) RevokeSession(ctx context.Context, req *authpb.RevokeSessionRequest) (*authpb.RevokeSessionResponse, error) {
+	caller, err := authint.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Чужие сессии не отличаем от несуществующих
+	session, err := s.sessions.GetSession(ctx, req.SessionId)
+	if err != nil && !errors.Is(err, store.ErrSessionNotFound) {
+		log.Printf("Ошибка при получении сессии: %v", err)
+		return nil, status.Error(codes.Internal, "Could not load session")
+	}
+	if session == nil || session.UserID != caller.UserID {
+		return &authpb.RevokeSessionResponse{Success: false, Message: "Session not found"}, nil
+	}
+
+	if err := s.revokeSession(ctx, req.SessionId); err != nil {
+		log.Printf("Ошибка при отзыве сессии %s: %v", req.SessionId, err)
+		return nil, status.Error(codes.Internal, "Could not revoke session")
+	}
+	s.auditCaller(ctx, auditSessionRevoke, models.AuditOutcomeSuccess, "session "+req.SessionId)
+	return &authpb.RevokeSessionResponse{Success: true, Message: "Session revoked"}, nil
+}
+
+// RevokeOtherSessions - "выйти на всех остальных устройствах"
+func (s *server) RevokeOtherSessions(ctx context.Context, req *authpb.RevokeOtherSessionsRequest) (*authpb.RevokeOtherSessionsResponse, error) {
+	caller, err := authint.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	revoked, err := s.revokeUserSessions(ctx, caller.UserID, caller.SessionID)
+	if err != nil {
+		log.Printf("Ошибка при отзыве сессий пользователя %d: %v", caller.UserID, err)
+		return nil, status.Error(codes.Internal, "Could not revoke sessions")
+	}
+	s.auditCaller(ctx, auditSessionRevoke, models.AuditOutcomeSuccess, fmt.Sprintf("revoked %d other sessions", revoked))
+	return &authpb.RevokeOtherSessionsResponse{Revoked: revoked}, nil
+}
+
+// revokeUserSessions завершает все сессии пользователя, кроме keepSessionID
+// (пустая строка - все). Refresh-токены отзываются и у уже истёкших сессий.
+func (s *server) revokeUserSessions(ctx context.Context, userID int32, keepSessionID string) (int32, error) {
+	sessions, err := s.sessions.ListUserSessions(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	var revoked int32
+	for _, session := range sessions {
+		if session.ID == keepSessionID {
+			continue
+		}
+		if err := s.sessions.DeleteSession(ctx, session.ID); err != nil {
+			return revoked, err
+		}
+		revoked++
+	}
+
+	return revoked, s.refreshTokens.RevokeUserRefreshTokens(ctx, userID, keepSessionID)
+}
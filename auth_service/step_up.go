@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	authpb "fin-trans/auth_service/proto"
+	"fin-trans/auth_service/store"
+	models "fin-trans/models_package"
+)
+
+// Длина одноразового кода подтверждения операции
+const stepUpCodeDigits = 6
+
+// IssueStepUpCode отправляет пользователю одноразовый код для подтверждения операции.
+// Вызывается сервисами, description попадает в текст уведомления.
+func (s *server) IssueStepUpCode(ctx context.Context, req *authpb.IssueStepUpCodeRequest) (*authpb.IssueStepUpCodeResponse, error) {
+	user, err := s.users.GetUserByID(ctx, req.UserId)
+	if errors.Is(err, store.ErrUserNotFound) {
+		return nil, status.Error(codes.NotFound, "User not found")
+	}
+	if err != nil {
+		log.Printf("Ошибка при получении пользователя: %v", err)
+		return nil, status.Error(codes.Internal, "Could not load user")
+	}
+	if user.Status != models.UserStatusActive {
+		return nil, status.Error(codes.FailedPrecondition, "User is not active")
+	}
+
+	code, err := randomStepUpCode()
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Could not generate code")
+	}
+	id, err := randomToken()
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Could not generate code")
+	}
+
+	// Код проверяет VerifyStepUpCode, который может попасть на другую реплику
+	expiresAt := time.Now().Add(s.totpCfg.StepUpTTL)
+	err = s.sessions.SaveChallenge(ctx, &models.AuthChallenge{
+		ID:        id,
+		Kind:      models.AuthChallengeStepUp,
+		UserID:    user.ID,
+		CodeHash:  hashToken(code),
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		log.Printf("Ошибка при сохранении кода подтверждения: %v", err)
+		return nil, status.Error(codes.Internal, "Could not generate code")
+	}
+
+	s.audit(ctx, models.AuditEvent{Event: auditStepUpIssue, UserID: user.ID, Username: user.Username, Outcome: models.AuditOutcomeSuccess, Detail: req.Description})
+	s.notify(ctx, user, "Confirmation code",
+		fmt.Sprintf("Your code to confirm %s: %s\nIt expires in %v. If it wasn't you, do not share the code.", req.Description, code, s.totpCfg.StepUpTTL))
+	return &authpb.IssueStepUpCodeResponse{ChallengeId: id, ExpiresAt: expiresAt.Unix()}, nil
+}
+
+// VerifyStepUpCode проверяет код. Верный код одноразовый, после maxChallengeAttempts
+// неверных попыток операцию нужно начинать заново.
+func (s *server) VerifyStepUpCode(ctx context.Context, req *authpb.VerifyStepUpCodeRequest) (*authpb.VerifyStepUpCodeResponse, error) {
+	valid, exhausted, reason, err := s.checkStepUpCode(ctx, req.ChallengeId, req.UserId, strings.TrimSpace(req.Code))
+	if err != nil {
+		log.Printf("Ошибка при проверке кода подтверждения: %v", err)
+		return nil, status.Error(codes.Internal, "Could not verify code")
+	}
+
+	event := models.AuditEvent{Event: auditStepUpVerify, UserID: req.UserId, Outcome: models.AuditOutcomeSuccess}
+	if !valid {
+		event.Outcome = models.AuditOutcomeFailure
+		event.Detail = reason
+	}
+	s.audit(ctx, event)
+
+	if !valid {
+		return &authpb.VerifyStepUpCodeResponse{Valid: false, Message: "Invalid or expired code", AttemptsExhausted: exhausted}, nil
+	}
+	return &authpb.VerifyStepUpCodeResponse{Valid: true, Message: "Confirmed"}, nil
+}
+
+// checkStepUpCode возвращает причину отказа для журнала аудита. exhausted - неверный
+// код был последней попыткой и challenge удалён.
+func (s *server) checkStepUpCode(ctx context.Context, id string, userID int32, code string) (valid, exhausted bool, reason string, err error) {
+	challenge, err := s.sessions.GetChallenge(ctx, id)
+	if errors.Is(err, store.ErrChallengeNotFound) {
+		return false, false, "unknown challenge", nil
+	}
+	if err != nil {
+		return false, false, "", err
+	}
+	if challenge.Kind != models.AuthChallengeStepUp || challenge.UserID != userID {
+		return false, false, "unknown challenge", nil
+	}
+	if time.Now().After(challenge.ExpiresAt) {
+		_, err := s.sessions.DeleteChallenge(ctx, id)
+		return false, false, "expired challenge", err
+	}
+	if subtle.ConstantTimeCompare([]byte(hashToken(code)), []byte(challenge.CodeHash)) != 1 {
+		attempts, err := s.sessions.AddChallengeAttempt(ctx, id)
+		if errors.Is(err, store.ErrChallengeNotFound) {
+			return false, false, "unknown challenge", nil
+		}
+		if err != nil {
+			return false, false, "", err
+		}
+		if attempts >= maxChallengeAttempts {
+			_, err := s.sessions.DeleteChallenge(ctx, id)
+			return false, true, "invalid code, attempts exhausted", err
+		}
+		return false, false, "invalid code", nil
+	}
+	// Верный код, параллельно введённый на другой реплике, проходит один раз
+	deleted, err := s.sessions.DeleteChallenge(ctx, id)
+	if err != nil {
+		return false, false, "", err
+	}
+	if !deleted {
+		return false, false, "challenge already used", nil
+	}
+	return true, false, "", nil
+}
+
+func randomStepUpCode() (string, error) {
+	max := big.NewInt(1)
+	for i := 0; i < stepUpCodeDigits; i++ {
+		max.Mul(max, big.NewInt(10))
+	}
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", stepUpCodeDigits, n), nil
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	authpb "fin-trans/auth_service/proto"
+	"fin-trans/auth_service/store"
+	models "fin-trans/models_package"
+)
+
+func TestVerifyStepUpCodeAttempts(t *testing.T) {
+	ctx := context.Background()
+	s := &server{sessions: store.NewMemorySessionStore(), auditLog: store.NewMemoryAuditStore()}
+	err := s.sessions.SaveChallenge(ctx, &models.AuthChallenge{
+		ID:        "step-up",
+		Kind:      models.AuthChallengeStepUp,
+		UserID:    1,
+		CodeHash:  hashToken("123456"),
+		ExpiresAt: time.Now().Add(time.Minute),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Последняя неверная попытка удаляет challenge и сообщает об этом, дальше код неизвестен
+	for i := 1; i <= maxChallengeAttempts+1; i++ {
+		res, err := s.VerifyStepUpCode(ctx, &authpb.VerifyStepUpCodeRequest{ChallengeId: "step-up", UserId: 1, Code: "000000"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Valid || res.AttemptsExhausted != (i == maxChallengeAttempts) {
+			t.Fatalf("attempt %d: VerifyStepUpCode() = %+v, want exhausted only on attempt %d", i, res, maxChallengeAttempts)
+		}
+	}
+	res, err := s.VerifyStepUpCode(ctx, &authpb.VerifyStepUpCodeRequest{ChallengeId: "step-up", UserId: 1, Code: "123456"})
+	if err != nil || res.Valid {
+		t.Fatalf("VerifyStepUpCode() with the right code after exhaustion = %+v, %v, want invalid", res, err)
+	}
+}
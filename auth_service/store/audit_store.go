@@ -0,0 +1,81 @@
+package store
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	models "fin-trans/models_package"
+)
+
+// AuditFilter - условия выборки журнала; пустые поля не фильтруют
+type AuditFilter struct {
+	Username string
+	Event    string
+	Outcome  string
+	Since    time.Time
+	Until    time.Time
+	BeforeID int64 // для постраничного чтения: только записи с ID меньше этого
+	Limit    int   // 0 - без ограничения
+}
+
+// AuditStore - журнал аудита, в который можно только дописывать
+type AuditStore interface {
+	// AppendAuditEvent присваивает записи ID, PrevHash и Hash и сохраняет её в конец цепочки
+	AppendAuditEvent(ctx context.Context, event *models.AuditEvent) error
+	// ListAuditEvents возвращает записи от новых к старым
+	ListAuditEvents(ctx context.Context, filter AuditFilter) ([]models.AuditEvent, error)
+	// ListAuditChain возвращает весь журнал от старых записей к новым для проверки цепочки
+	ListAuditChain(ctx context.Context) ([]models.AuditEvent, error)
+}
+
+// chainAuditEvent связывает запись с предыдущей и вычисляет её хеш.
+// Время округляется до микросекунд - точности TIMESTAMPTZ в PostgreSQL.
+func chainAuditEvent(prevHash string, event *models.AuditEvent) {
+	event.Timestamp = event.Timestamp.UTC().Truncate(time.Microsecond)
+	event.PrevHash = prevHash
+	event.Hash = AuditEventHash(event)
+}
+
+// AuditEventHash - SHA-256 от PrevHash и содержимого записи (без ID и самого Hash)
+func AuditEventHash(event *models.AuditEvent) string {
+	data, _ := json.Marshal(struct {
+		PrevHash  string `json:"prev_hash"`
+		Timestamp string `json:"timestamp"`
+		Event     string `json:"event"`
+		UserID    int32  `json:"user_id"`
+		Username  string `json:"username"`
+		IP        string `json:"ip"`
+		UserAgent string `json:"user_agent"`
+		Outcome   string `json:"outcome"`
+		Detail    string `json:"detail"`
+	}{
+		PrevHash:  event.PrevHash,
+		Timestamp: event.Timestamp.UTC().Format(time.RFC3339Nano),
+		Event:     event.Event,
+		UserID:    event.UserID,
+		Username:  event.Username,
+		IP:        event.IP,
+		UserAgent: event.UserAgent,
+		Outcome:   event.Outcome,
+		Detail:    event.Detail,
+	})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// VerifyAuditChain проверяет журнал, упорядоченный от старых записей к новым.
+// Возвращает ID первой записи, на которой цепочка нарушена, или 0.
+func VerifyAuditChain(events []models.AuditEvent) int64 {
+	prevHash := ""
+	for i := range events {
+		event := &events[i]
+		if event.PrevHash != prevHash || AuditEventHash(event) != event.Hash {
+			return event.ID
+		}
+		prevHash = event.Hash
+	}
+	return 0
+}
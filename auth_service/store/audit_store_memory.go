@@ -0,0 +1,63 @@
+package store
+
+import (
+	"context"
+	"sync"
+
+	models "fin-trans/models_package"
+)
+
+// MemoryAuditStore хранит журнал в памяти процесса
+type MemoryAuditStore struct {
+	mu     sync.Mutex
+	events []models.AuditEvent
+}
+
+func NewMemoryAuditStore() *MemoryAuditStore {
+	return &MemoryAuditStore{}
+}
+
+func (s *MemoryAuditStore) AppendAuditEvent(ctx context.Context, event *models.AuditEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prevHash := ""
+	if len(s.events) > 0 {
+		prevHash = s.events[len(s.events)-1].Hash
+	}
+	chainAuditEvent(prevHash, event)
+	event.ID = int64(len(s.events) + 1)
+	s.events = append(s.events, *event)
+	return nil
+}
+
+func (s *MemoryAuditStore) ListAuditEvents(ctx context.Context, filter AuditFilter) ([]models.AuditEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var events []models.AuditEvent
+	for i := len(s.events) - 1; i >= 0; i-- {
+		e := s.events[i]
+		switch {
+		case filter.Username != "" && e.Username != filter.Username,
+			filter.Event != "" && e.Event != filter.Event,
+			filter.Outcome != "" && e.Outcome != filter.Outcome,
+			!filter.Since.IsZero() && e.Timestamp.Before(filter.Since),
+			!filter.Until.IsZero() && !e.Timestamp.Before(filter.Until),
+			filter.BeforeID > 0 && e.ID >= filter.BeforeID:
+			continue
+		}
+		events = append(events, e)
+		if filter.Limit > 0 && len(events) == filter.Limit {
+			break
+		}
+	}
+	return events, nil
+}
+
+func (s *MemoryAuditStore) ListAuditChain(ctx context.Context) ([]models.AuditEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]models.AuditEvent(nil), s.events...), nil
+}
@@ -0,0 +1,142 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	models "fin-trans/models_package"
+)
+
+// Изменить или удалить запись журнала не даёт триггер; цепочка хешей
+// выявляет правки в обход него (например, с отключёнными триггерами)
+const auditSchema = `
+CREATE TABLE IF NOT EXISTS audit_events (
+	id         BIGSERIAL PRIMARY KEY,
+	ts         TIMESTAMPTZ NOT NULL,
+	event      TEXT NOT NULL,
+	user_id    INTEGER NOT NULL DEFAULT 0,
+	username   TEXT NOT NULL DEFAULT '',
+	ip         TEXT NOT NULL DEFAULT '',
+	user_agent TEXT NOT NULL DEFAULT '',
+	outcome    TEXT NOT NULL,
+	detail     TEXT NOT NULL DEFAULT '',
+	prev_hash  TEXT NOT NULL,
+	hash       TEXT NOT NULL UNIQUE
+);
+CREATE INDEX IF NOT EXISTS audit_events_username_idx ON audit_events (username);
+CREATE OR REPLACE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+BEGIN
+	RAISE EXCEPTION 'audit_events is append-only';
+END
+$$ LANGUAGE plpgsql;
+DROP TRIGGER IF EXISTS audit_events_append_only ON audit_events;
+CREATE TRIGGER audit_events_append_only BEFORE UPDATE OR DELETE ON audit_events
+	FOR EACH ROW EXECUTE FUNCTION audit_events_append_only()`
+
+// Ключ advisory-блокировки, под которой дописывается цепочка
+const auditChainLock = 7_281_001
+
+const auditColumns = "id, ts, event, user_id, username, ip, user_agent, outcome, detail, prev_hash, hash"
+
+// PostgresAuditStore хранит журнал в таблице audit_events
+type PostgresAuditStore struct {
+	db *sql.DB
+}
+
+func NewPostgresAuditStore(db *sql.DB) (*PostgresAuditStore, error) {
+	if _, err := db.Exec(auditSchema); err != nil {
+		return nil, fmt.Errorf("не удалось создать таблицу audit_events: %w", err)
+	}
+	return &PostgresAuditStore{db: db}, nil
+}
+
+func (s *PostgresAuditStore) AppendAuditEvent(ctx context.Context, event *models.AuditEvent) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Реплики дописывают журнал по очереди, иначе две записи сослались бы на один PrevHash
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock($1)", auditChainLock); err != nil {
+		return err
+	}
+	var prevHash string
+	err = tx.QueryRowContext(ctx, "SELECT hash FROM audit_events ORDER BY id DESC LIMIT 1").Scan(&prevHash)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+
+	chainAuditEvent(prevHash, event)
+	err = tx.QueryRowContext(ctx, `INSERT INTO audit_events (ts, event, user_id, username, ip, user_agent, outcome, detail, prev_hash, hash)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id`,
+		event.Timestamp, event.Event, event.UserID, event.Username, event.IP, event.UserAgent,
+		event.Outcome, event.Detail, event.PrevHash, event.Hash).Scan(&event.ID)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *PostgresAuditStore) ListAuditEvents(ctx context.Context, filter AuditFilter) ([]models.AuditEvent, error) {
+	var conditions []string
+	var args []interface{}
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+	if filter.Username != "" {
+		add("username = $%d", filter.Username)
+	}
+	if filter.Event != "" {
+		add("event = $%d", filter.Event)
+	}
+	if filter.Outcome != "" {
+		add("outcome = $%d", filter.Outcome)
+	}
+	if !filter.Since.IsZero() {
+		add("ts >= $%d", filter.Since)
+	}
+	if !filter.Until.IsZero() {
+		add("ts < $%d", filter.Until)
+	}
+	if filter.BeforeID > 0 {
+		add("id < $%d", filter.BeforeID)
+	}
+
+	query := "SELECT " + auditColumns + " FROM audit_events"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY id DESC"
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", filter.Limit)
+	}
+	return s.query(ctx, query, args...)
+}
+
+func (s *PostgresAuditStore) ListAuditChain(ctx context.Context) ([]models.AuditEvent, error) {
+	return s.query(ctx, "SELECT "+auditColumns+" FROM audit_events ORDER BY id")
+}
+
+func (s *PostgresAuditStore) query(ctx context.Context, query string, args ...interface{}) ([]models.AuditEvent, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []models.AuditEvent
+	for rows.Next() {
+		var e models.AuditEvent
+		err := rows.Scan(&e.ID, &e.Timestamp, &e.Event, &e.UserID, &e.Username, &e.IP, &e.UserAgent,
+			&e.Outcome, &e.Detail, &e.PrevHash, &e.Hash)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
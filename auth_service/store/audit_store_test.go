@@ -0,0 +1,98 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	models "fin-trans/models_package"
+)
+
+func auditChain(t *testing.T, n int) []models.AuditEvent {
+	t.Helper()
+	ctx := context.Background()
+	s := NewMemoryAuditStore()
+	start := time.Date(2026, 3, 15, 12, 0, 0, 123456789, time.FixedZone("MSK", 3*60*60))
+	for i := 0; i < n; i++ {
+		event := &models.AuditEvent{
+			Timestamp: start.Add(time.Duration(i) * time.Minute),
+			Event:     "login",
+			UserID:    int32(i + 1),
+			Username:  "user",
+			IP:        "10.0.0.1",
+			UserAgent: "test",
+			Outcome:   models.AuditOutcomeSuccess,
+		}
+		if err := s.AppendAuditEvent(ctx, event); err != nil {
+			t.Fatal(err)
+		}
+	}
+	chain, err := s.ListAuditChain(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return chain
+}
+
+func TestVerifyAuditChain(t *testing.T) {
+	tests := []struct {
+		name   string
+		tamper func(chain []models.AuditEvent) []models.AuditEvent
+		want   int64
+	}{
+		{"intact", func(c []models.AuditEvent) []models.AuditEvent { return c }, 0},
+		{"empty", func(c []models.AuditEvent) []models.AuditEvent { return nil }, 0},
+		{"changed detail", func(c []models.AuditEvent) []models.AuditEvent {
+			c[2].Detail = "edited"
+			return c
+		}, 3},
+		{"changed outcome", func(c []models.AuditEvent) []models.AuditEvent {
+			c[1].Outcome = models.AuditOutcomeFailure
+			return c
+		}, 2},
+		{"changed timestamp", func(c []models.AuditEvent) []models.AuditEvent {
+			c[4].Timestamp = c[4].Timestamp.Add(time.Second)
+			return c
+		}, 5},
+		{"rehashed record breaks the next link", func(c []models.AuditEvent) []models.AuditEvent {
+			c[2].Username = "someone else"
+			c[2].Hash = AuditEventHash(&c[2])
+			return c
+		}, 4},
+		{"deleted record", func(c []models.AuditEvent) []models.AuditEvent {
+			return append(c[:2], c[3:]...)
+		}, 4},
+		{"swapped records", func(c []models.AuditEvent) []models.AuditEvent {
+			c[1], c[2] = c[2], c[1]
+			return c
+		}, 3},
+		{"deleted first record", func(c []models.AuditEvent) []models.AuditEvent {
+			return c[1:]
+		}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chain := tt.tamper(auditChain(t, 5))
+			if got := VerifyAuditChain(chain); got != tt.want {
+				t.Fatalf("VerifyAuditChain() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// Хеш считается от времени, округлённого до микросекунд, в UTC, поэтому запись,
+// прочитанная из PostgreSQL в другой зоне, проверяется так же
+func TestAuditEventHashStableAcrossStorage(t *testing.T) {
+	chain := auditChain(t, 2)
+	stored := chain[0]
+	if stored.Timestamp.Nanosecond()%1000 != 0 {
+		t.Fatalf("Timestamp %v is not truncated to microseconds", stored.Timestamp)
+	}
+	stored.Timestamp = stored.Timestamp.In(time.FixedZone("EST", -5*60*60))
+	if got := AuditEventHash(&stored); got != stored.Hash {
+		t.Fatalf("AuditEventHash() = %s after zone change, want %s", got, stored.Hash)
+	}
+	if chain[1].PrevHash != chain[0].Hash {
+		t.Fatalf("PrevHash = %s, want %s", chain[1].PrevHash, chain[0].Hash)
+	}
+}
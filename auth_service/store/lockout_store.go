@@ -0,0 +1,21 @@
+package store
+
+import (
+	"context"
+	"time"
+)
+
+// LockoutStore хранит счётчики неудачных входов и блокировки по ключам вида
+// "user:<имя>" и "ip:<адрес>". Общее хранилище нужно, чтобы перебор, размазанный
+// по репликам auth_service, упирался в тот же порог, что и на одной реплике.
+type LockoutStore interface {
+	// AddFailure учитывает неудачу и возвращает число неудач подряд. Счётчик
+	// начинается заново, если с прошлой неудачи прошло больше resetAfter.
+	AddFailure(ctx context.Context, key string, resetAfter time.Duration) (int, error)
+	// Lock блокирует ключ до until
+	Lock(ctx context.Context, key string, until time.Time) error
+	// LockedUntil возвращает конец блокировки или нулевое время, если ключ не заблокирован
+	LockedUntil(ctx context.Context, key string) (time.Time, error)
+	// Reset удаляет счётчик и блокировку и сообщает, были ли они
+	Reset(ctx context.Context, key string) (bool, error)
+}
@@ -0,0 +1,91 @@
+package store
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryLockoutStore хранит счётчики неудачных входов в памяти процесса.
+// Подходит для тестов и одной реплики: каждая реплика считает неудачи отдельно.
+type MemoryLockoutStore struct {
+	mu        sync.Mutex
+	counters  map[string]*lockoutCounter
+	lastPrune time.Time
+}
+
+type lockoutCounter struct {
+	failures    int
+	lastFailure time.Time
+	resetAfter  time.Duration
+	lockedUntil time.Time
+}
+
+func NewMemoryLockoutStore() *MemoryLockoutStore {
+	return &MemoryLockoutStore{counters: make(map[string]*lockoutCounter)}
+}
+
+func (s *MemoryLockoutStore) AddFailure(ctx context.Context, key string, resetAfter time.Duration) (int, error) {
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.counters[key]
+	if !ok {
+		c = &lockoutCounter{}
+		s.counters[key] = c
+	}
+	if now.Sub(c.lastFailure) > resetAfter {
+		c.failures = 0
+	}
+	c.failures++
+	c.lastFailure = now
+	c.resetAfter = resetAfter
+	if now.Sub(s.lastPrune) > time.Minute {
+		s.prune(now)
+	}
+	return c.failures, nil
+}
+
+func (s *MemoryLockoutStore) Lock(ctx context.Context, key string, until time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.counters[key]
+	if !ok {
+		c = &lockoutCounter{lastFailure: time.Now()}
+		s.counters[key] = c
+	}
+	c.lockedUntil = until
+	return nil
+}
+
+func (s *MemoryLockoutStore) LockedUntil(ctx context.Context, key string) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.counters[key]
+	if !ok || !c.lockedUntil.After(time.Now()) {
+		return time.Time{}, nil
+	}
+	return c.lockedUntil, nil
+}
+
+func (s *MemoryLockoutStore) Reset(ctx context.Context, key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, exists := s.counters[key]
+	delete(s.counters, key)
+	return exists, nil
+}
+
+// prune удаляет сброшенные счётчики без действующей блокировки
+func (s *MemoryLockoutStore) prune(now time.Time) {
+	for key, c := range s.counters {
+		if now.Sub(c.lastFailure) > c.resetAfter && c.lockedUntil.Before(now) {
+			delete(s.counters, key)
+		}
+	}
+	s.lastPrune = now
+}
@@ -0,0 +1,69 @@
+package store
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// RedisLockoutStore хранит число неудач строкой login_failures:<ключ> с TTL resetAfter,
+// который продлевается каждой неудачей, а блокировку - строкой login_lock:<ключ>
+// с концом блокировки в наносекундах и TTL до него.
+type RedisLockoutStore struct {
+	rdb *redis.Client
+}
+
+func NewRedisLockoutStore(rdb *redis.Client) *RedisLockoutStore {
+	return &RedisLockoutStore{rdb: rdb}
+}
+
+func loginFailuresKey(key string) string {
+	return "login_failures:" + key
+}
+
+func loginLockKey(key string) string {
+	return "login_lock:" + key
+}
+
+func (s *RedisLockoutStore) AddFailure(ctx context.Context, key string, resetAfter time.Duration) (int, error) {
+	var incr *redis.IntCmd
+	_, err := s.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, loginFailuresKey(key))
+		pipe.PExpire(ctx, loginFailuresKey(key), resetAfter)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(incr.Val()), nil
+}
+
+func (s *RedisLockoutStore) Lock(ctx context.Context, key string, until time.Time) error {
+	ttl := time.Until(until)
+	if ttl <= 0 {
+		return nil
+	}
+	return s.rdb.Set(ctx, loginLockKey(key), until.UnixNano(), ttl).Err()
+}
+
+func (s *RedisLockoutStore) LockedUntil(ctx context.Context, key string) (time.Time, error) {
+	value, err := s.rdb.Get(ctx, loginLockKey(key)).Result()
+	if err == redis.Nil {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	nanos, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(0, nanos), nil
+}
+
+func (s *RedisLockoutStore) Reset(ctx context.Context, key string) (bool, error) {
+	n, err := s.rdb.Del(ctx, loginFailuresKey(key), loginLockKey(key)).Result()
+	return n > 0, err
+}
@@ -0,0 +1,60 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryLockoutStore(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryLockoutStore()
+
+	for want := 1; want <= 3; want++ {
+		if got, err := s.AddFailure(ctx, "user:alice", time.Hour); err != nil || got != want {
+			t.Fatalf("AddFailure() = %d, %v, want %d", got, err, want)
+		}
+	}
+	if until, _ := s.LockedUntil(ctx, "user:alice"); !until.IsZero() {
+		t.Fatalf("LockedUntil() = %v before Lock, want zero", until)
+	}
+	until := time.Now().Add(time.Minute)
+	if err := s.Lock(ctx, "user:alice", until); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := s.LockedUntil(ctx, "user:alice"); !got.Equal(until) {
+		t.Fatalf("LockedUntil() = %v, want %v", got, until)
+	}
+	if got, _ := s.LockedUntil(ctx, "ip:10.0.0.1"); !got.IsZero() {
+		t.Fatalf("LockedUntil() of another key = %v, want zero", got)
+	}
+
+	// Истёкшая блокировка не действует
+	if err := s.Lock(ctx, "ip:10.0.0.1", time.Now().Add(-time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := s.LockedUntil(ctx, "ip:10.0.0.1"); !got.IsZero() {
+		t.Fatalf("LockedUntil() after expiry = %v, want zero", got)
+	}
+
+	if ok, err := s.Reset(ctx, "user:alice"); err != nil || !ok {
+		t.Fatalf("Reset() = %v, %v, want true", ok, err)
+	}
+	if ok, _ := s.Reset(ctx, "user:alice"); ok {
+		t.Fatalf("Reset() of a reset key = true, want false")
+	}
+	if got, _ := s.AddFailure(ctx, "user:alice", time.Hour); got != 1 {
+		t.Fatalf("AddFailure() after Reset = %d, want 1", got)
+	}
+}
+
+func TestMemoryLockoutStoreResetAfter(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryLockoutStore()
+	s.AddFailure(ctx, "user:alice", 10*time.Millisecond)
+	s.AddFailure(ctx, "user:alice", 10*time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	if got, _ := s.AddFailure(ctx, "user:alice", 10*time.Millisecond); got != 1 {
+		t.Fatalf("AddFailure() after resetAfter = %d, want 1", got)
+	}
+}
@@ -0,0 +1,20 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	models "fin-trans/models_package"
+)
+
+var ErrPasswordResetNotFound = errors.New("токен сброса пароля не найден")
+
+// PasswordResetStore хранит одноразовые токены сброса пароля
+type PasswordResetStore interface {
+	SavePasswordReset(ctx context.Context, token *models.PasswordResetToken) error
+	// ConsumePasswordReset атомарно помечает токен использованным и возвращает его.
+	// Возвращает ErrPasswordResetNotFound, если токена нет или он уже использован.
+	ConsumePasswordReset(ctx context.Context, tokenHash string) (*models.PasswordResetToken, error)
+	// InvalidateUserPasswordResets гасит все неиспользованные токены пользователя
+	InvalidateUserPasswordResets(ctx context.Context, userID int32) error
+}
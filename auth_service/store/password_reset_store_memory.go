@@ -0,0 +1,56 @@
+package store
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	models "fin-trans/models_package"
+)
+
+// MemoryPasswordResetStore хранит токены сброса в памяти процесса
+type MemoryPasswordResetStore struct {
+	mu     sync.Mutex
+	tokens map[string]*models.PasswordResetToken
+}
+
+func NewMemoryPasswordResetStore() *MemoryPasswordResetStore {
+	return &MemoryPasswordResetStore{tokens: make(map[string]*models.PasswordResetToken)}
+}
+
+func (s *MemoryPasswordResetStore) SavePasswordReset(ctx context.Context, token *models.PasswordResetToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	copied := *token
+	s.tokens[token.TokenHash] = &copied
+	return nil
+}
+
+func (s *MemoryPasswordResetStore) ConsumePasswordReset(ctx context.Context, tokenHash string) (*models.PasswordResetToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	token, exists := s.tokens[tokenHash]
+	if !exists || token.UsedAt != nil {
+		return nil, ErrPasswordResetNotFound
+	}
+	now := time.Now()
+	token.UsedAt = &now
+
+	copied := *token
+	return &copied, nil
+}
+
+func (s *MemoryPasswordResetStore) InvalidateUserPasswordResets(ctx context.Context, userID int32) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for _, token := range s.tokens {
+		if token.UserID == userID && token.UsedAt == nil {
+			token.UsedAt = &now
+		}
+	}
+	return nil
+}
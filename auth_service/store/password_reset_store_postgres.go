@@ -0,0 +1,57 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	models "fin-trans/models_package"
+)
+
+const passwordResetSchema = `
+CREATE TABLE IF NOT EXISTS password_reset_tokens (
+	token_hash TEXT PRIMARY KEY,
+	user_id    INTEGER NOT NULL REFERENCES users(id),
+	created_at TIMESTAMPTZ NOT NULL,
+	expires_at TIMESTAMPTZ NOT NULL,
+	used_at    TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS password_reset_tokens_user_id_idx ON password_reset_tokens (user_id)`
+
+// PostgresPasswordResetStore хранит токены сброса в таблице password_reset_tokens
+type PostgresPasswordResetStore struct {
+	db *sql.DB
+}
+
+func NewPostgresPasswordResetStore(db *sql.DB) (*PostgresPasswordResetStore, error) {
+	if _, err := db.Exec(passwordResetSchema); err != nil {
+		return nil, fmt.Errorf("не удалось создать таблицу password_reset_tokens: %w", err)
+	}
+	return &PostgresPasswordResetStore{db: db}, nil
+}
+
+func (s *PostgresPasswordResetStore) SavePasswordReset(ctx context.Context, token *models.PasswordResetToken) error {
+	_, err := s.db.ExecContext(ctx, "INSERT INTO password_reset_tokens (token_hash, user_id, created_at, expires_at) VALUES ($1, $2, $3, $4)",
+		token.TokenHash, token.UserID, token.CreatedAt, token.ExpiresAt)
+	return err
+}
+
+func (s *PostgresPasswordResetStore) ConsumePasswordReset(ctx context.Context, tokenHash string) (*models.PasswordResetToken, error) {
+	var token models.PasswordResetToken
+	row := s.db.QueryRowContext(ctx, `UPDATE password_reset_tokens SET used_at = now()
+		WHERE token_hash = $1 AND used_at IS NULL
+		RETURNING token_hash, user_id, created_at, expires_at, used_at`, tokenHash)
+	err := row.Scan(&token.TokenHash, &token.UserID, &token.CreatedAt, &token.ExpiresAt, &token.UsedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrPasswordResetNotFound
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (s *PostgresPasswordResetStore) InvalidateUserPasswordResets(ctx context.Context, userID int32) error {
+	_, err := s.db.ExecContext(ctx, "UPDATE password_reset_tokens SET used_at = now() WHERE user_id = $1 AND used_at IS NULL", userID)
+	return err
+}
@@ -0,0 +1,25 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	models "fin-trans/models_package"
+)
+
+var ErrRefreshTokenNotFound = errors.New("refresh-токен не найден")
+
+// RefreshTokenStore хранит выданные refresh-токены для ротации и обнаружения повторного использования
+type RefreshTokenStore interface {
+	SaveRefreshToken(ctx context.Context, token *models.RefreshToken) error
+	// GetRefreshToken возвращает ErrRefreshTokenNotFound, если токена нет
+	GetRefreshToken(ctx context.Context, tokenHash string) (*models.RefreshToken, error)
+	// MarkRefreshTokenRotated атомарно помечает токен использованным.
+	// Возвращает false, если токен уже был использован ранее.
+	MarkRefreshTokenRotated(ctx context.Context, tokenHash string) (bool, error)
+	// RevokeRefreshTokenFamily отзывает все токены семейства
+	RevokeRefreshTokenFamily(ctx context.Context, familyID string) error
+	// RevokeUserRefreshTokens отзывает все refresh-токены пользователя,
+	// кроме семейства exceptFamilyID (пустая строка - отозвать все)
+	RevokeUserRefreshTokens(ctx context.Context, userID int32, exceptFamilyID string) error
+}
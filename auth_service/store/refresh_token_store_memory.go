@@ -0,0 +1,75 @@
+package store
+
+import (
+	"context"
+	"sync"
+
+	models "fin-trans/models_package"
+)
+
+// MemoryRefreshTokenStore хранит refresh-токены в памяти процесса
+type MemoryRefreshTokenStore struct {
+	mu     sync.Mutex
+	tokens map[string]*models.RefreshToken
+}
+
+func NewMemoryRefreshTokenStore() *MemoryRefreshTokenStore {
+	return &MemoryRefreshTokenStore{tokens: make(map[string]*models.RefreshToken)}
+}
+
+func (s *MemoryRefreshTokenStore) SaveRefreshToken(ctx context.Context, token *models.RefreshToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	copied := *token
+	s.tokens[token.TokenHash] = &copied
+	return nil
+}
+
+func (s *MemoryRefreshTokenStore) GetRefreshToken(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	token, exists := s.tokens[tokenHash]
+	if !exists {
+		return nil, ErrRefreshTokenNotFound
+	}
+	copied := *token
+	return &copied, nil
+}
+
+func (s *MemoryRefreshTokenStore) MarkRefreshTokenRotated(ctx context.Context, tokenHash string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	token, exists := s.tokens[tokenHash]
+	if !exists || token.Rotated {
+		return false, nil
+	}
+	token.Rotated = true
+	return true, nil
+}
+
+func (s *MemoryRefreshTokenStore) RevokeRefreshTokenFamily(ctx context.Context, familyID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, token := range s.tokens {
+		if token.FamilyID == familyID {
+			token.Revoked = true
+		}
+	}
+	return nil
+}
+
+func (s *MemoryRefreshTokenStore) RevokeUserRefreshTokens(ctx context.Context, userID int32, exceptFamilyID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, token := range s.tokens {
+		if token.UserID == userID && token.FamilyID != exceptFamilyID {
+			token.Revoked = true
+		}
+	}
+	return nil
+}
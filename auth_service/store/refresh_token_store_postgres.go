@@ -0,0 +1,76 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	models "fin-trans/models_package"
+)
+
+const refreshTokensSchema = `
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+	token_hash TEXT PRIMARY KEY,
+	family_id  TEXT NOT NULL,
+	user_id    INTEGER NOT NULL REFERENCES users(id),
+	username   TEXT NOT NULL,
+	issued_at  TIMESTAMPTZ NOT NULL,
+	expires_at TIMESTAMPTZ NOT NULL,
+	rotated    BOOLEAN NOT NULL DEFAULT false,
+	revoked    BOOLEAN NOT NULL DEFAULT false
+);
+CREATE INDEX IF NOT EXISTS refresh_tokens_family_id_idx ON refresh_tokens (family_id);
+CREATE INDEX IF NOT EXISTS refresh_tokens_user_id_idx ON refresh_tokens (user_id)`
+
+// PostgresRefreshTokenStore хранит refresh-токены в таблице refresh_tokens
+type PostgresRefreshTokenStore struct {
+	db *sql.DB
+}
+
+func NewPostgresRefreshTokenStore(db *sql.DB) (*PostgresRefreshTokenStore, error) {
+	if _, err := db.Exec(refreshTokensSchema); err != nil {
+		return nil, fmt.Errorf("не удалось создать таблицу refresh_tokens: %w", err)
+	}
+	return &PostgresRefreshTokenStore{db: db}, nil
+}
+
+func (s *PostgresRefreshTokenStore) SaveRefreshToken(ctx context.Context, token *models.RefreshToken) error {
+	_, err := s.db.ExecContext(ctx, "INSERT INTO refresh_tokens (token_hash, family_id, user_id, username, issued_at, expires_at) VALUES ($1, $2, $3, $4, $5, $6)",
+		token.TokenHash, token.FamilyID, token.UserID, token.Username, token.IssuedAt, token.ExpiresAt)
+	return err
+}
+
+func (s *PostgresRefreshTokenStore) GetRefreshToken(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	row := s.db.QueryRowContext(ctx, "SELECT token_hash, family_id, user_id, username, issued_at, expires_at, rotated, revoked FROM refresh_tokens WHERE token_hash = $1", tokenHash)
+	err := row.Scan(&token.TokenHash, &token.FamilyID, &token.UserID, &token.Username, &token.IssuedAt, &token.ExpiresAt, &token.Rotated, &token.Revoked)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrRefreshTokenNotFound
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (s *PostgresRefreshTokenStore) MarkRefreshTokenRotated(ctx context.Context, tokenHash string) (bool, error) {
+	res, err := s.db.ExecContext(ctx, "UPDATE refresh_tokens SET rotated = true WHERE token_hash = $1 AND NOT rotated", tokenHash)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+func (s *PostgresRefreshTokenStore) RevokeRefreshTokenFamily(ctx context.Context, familyID string) error {
+	_, err := s.db.ExecContext(ctx, "UPDATE refresh_tokens SET revoked = true WHERE family_id = $1", familyID)
+	return err
+}
+
+func (s *PostgresRefreshTokenStore) RevokeUserRefreshTokens(ctx context.Context, userID int32, exceptFamilyID string) error {
+	_, err := s.db.ExecContext(ctx, "UPDATE refresh_tokens SET revoked = true WHERE user_id = $1 AND family_id <> $2", userID, exceptFamilyID)
+	return err
+}
@@ -0,0 +1,30 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	models "fin-trans/models_package"
+)
+
+var (
+	ErrServiceAccountExists   = errors.New("сервисный аккаунт уже существует")
+	ErrServiceAccountNotFound = errors.New("сервисный аккаунт не найден")
+	ErrAPIKeyNotFound         = errors.New("API-ключ не найден")
+)
+
+// ServiceAccountStore хранит сервисные аккаунты и хеши их API-ключей
+type ServiceAccountStore interface {
+	CreateServiceAccount(ctx context.Context, name, description string) (*models.ServiceAccount, error)
+	GetServiceAccount(ctx context.Context, id int32) (*models.ServiceAccount, error)
+	ListServiceAccounts(ctx context.Context) ([]models.ServiceAccount, error)
+
+	CreateAPIKey(ctx context.Context, key *models.APIKey) error
+	// GetAPIKey возвращает и отозванные ключи, решение принимает вызывающий
+	GetAPIKey(ctx context.Context, id string) (*models.APIKey, error)
+	ListAPIKeys(ctx context.Context, serviceAccountID int32) ([]models.APIKey, error)
+	// RevokeAPIKey возвращает ErrAPIKeyNotFound, если ключа нет или он уже отозван
+	RevokeAPIKey(ctx context.Context, id string) error
+	TouchAPIKey(ctx context.Context, id string, at time.Time) error
+}
@@ -0,0 +1,129 @@
+package store
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	models "fin-trans/models_package"
+)
+
+// MemoryServiceAccountStore хранит сервисные аккаунты в памяти процесса
+type MemoryServiceAccountStore struct {
+	mu       sync.Mutex
+	nextID   int32
+	accounts map[int32]*models.ServiceAccount
+	keys     map[string]*models.APIKey
+}
+
+func NewMemoryServiceAccountStore() *MemoryServiceAccountStore {
+	return &MemoryServiceAccountStore{
+		nextID:   1,
+		accounts: make(map[int32]*models.ServiceAccount),
+		keys:     make(map[string]*models.APIKey),
+	}
+}
+
+func (s *MemoryServiceAccountStore) CreateServiceAccount(ctx context.Context, name, description string) (*models.ServiceAccount, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, account := range s.accounts {
+		if account.Name == name {
+			return nil, ErrServiceAccountExists
+		}
+	}
+	account := &models.ServiceAccount{ID: s.nextID, Name: name, Description: description, CreatedAt: time.Now()}
+	s.accounts[account.ID] = account
+	s.nextID++
+
+	copied := *account
+	return &copied, nil
+}
+
+func (s *MemoryServiceAccountStore) GetServiceAccount(ctx context.Context, id int32) (*models.ServiceAccount, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	account, exists := s.accounts[id]
+	if !exists {
+		return nil, ErrServiceAccountNotFound
+	}
+	copied := *account
+	return &copied, nil
+}
+
+func (s *MemoryServiceAccountStore) ListServiceAccounts(ctx context.Context) ([]models.ServiceAccount, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	accounts := make([]models.ServiceAccount, 0, len(s.accounts))
+	for _, account := range s.accounts {
+		accounts = append(accounts, *account)
+	}
+	sort.Slice(accounts, func(i, j int) bool { return accounts[i].ID < accounts[j].ID })
+	return accounts, nil
+}
+
+func (s *MemoryServiceAccountStore) CreateAPIKey(ctx context.Context, key *models.APIKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.accounts[key.ServiceAccountID]; !exists {
+		return ErrServiceAccountNotFound
+	}
+	copied := *key
+	copied.Scopes = append([]string(nil), key.Scopes...)
+	s.keys[key.ID] = &copied
+	return nil
+}
+
+func (s *MemoryServiceAccountStore) GetAPIKey(ctx context.Context, id string) (*models.APIKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, exists := s.keys[id]
+	if !exists {
+		return nil, ErrAPIKeyNotFound
+	}
+	copied := *key
+	return &copied, nil
+}
+
+func (s *MemoryServiceAccountStore) ListAPIKeys(ctx context.Context, serviceAccountID int32) ([]models.APIKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var keys []models.APIKey
+	for _, key := range s.keys {
+		if key.ServiceAccountID == serviceAccountID {
+			keys = append(keys, *key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].CreatedAt.Before(keys[j].CreatedAt) })
+	return keys, nil
+}
+
+func (s *MemoryServiceAccountStore) RevokeAPIKey(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, exists := s.keys[id]
+	if !exists || key.RevokedAt != nil {
+		return ErrAPIKeyNotFound
+	}
+	now := time.Now()
+	key.RevokedAt = &now
+	return nil
+}
+
+func (s *MemoryServiceAccountStore) TouchAPIKey(ctx context.Context, id string, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if key, exists := s.keys[id]; exists {
+		key.LastUsedAt = &at
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	models "fin-trans/models_package"
+)
+
+const serviceAccountsSchema = `
+CREATE TABLE IF NOT EXISTS service_accounts (
+	id          SERIAL PRIMARY KEY,
+	name        TEXT NOT NULL UNIQUE,
+	description TEXT NOT NULL DEFAULT '',
+	created_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE TABLE IF NOT EXISTS api_keys (
+	id                 TEXT PRIMARY KEY,
+	service_account_id INTEGER NOT NULL REFERENCES service_accounts(id),
+	name               TEXT NOT NULL DEFAULT '',
+	key_hash           TEXT NOT NULL,
+	scopes             TEXT[] NOT NULL,
+	created_at         TIMESTAMPTZ NOT NULL,
+	last_used_at       TIMESTAMPTZ,
+	revoked_at         TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS api_keys_service_account_id_idx ON api_keys (service_account_id)`
+
+const apiKeyColumns = "id, service_account_id, name, key_hash, scopes, created_at, last_used_at, revoked_at"
+
+// PostgresServiceAccountStore хранит сервисные аккаунты в таблицах service_accounts и api_keys
+type PostgresServiceAccountStore struct {
+	db *sql.DB
+}
+
+func NewPostgresServiceAccountStore(db *sql.DB) (*PostgresServiceAccountStore, error) {
+	if _, err := db.Exec(serviceAccountsSchema); err != nil {
+		return nil, fmt.Errorf("не удалось создать таблицы сервисных аккаунтов: %w", err)
+	}
+	return &PostgresServiceAccountStore{db: db}, nil
+}
+
+func (s *PostgresServiceAccountStore) CreateServiceAccount(ctx context.Context, name, description string) (*models.ServiceAccount, error) {
+	account := &models.ServiceAccount{Name: name, Description: description}
+	row := s.db.QueryRowContext(ctx, "INSERT INTO service_accounts (name, description) VALUES ($1, $2) RETURNING id, created_at", name, description)
+	if err := row.Scan(&account.ID, &account.CreatedAt); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == pqUniqueViolation {
+			return nil, ErrServiceAccountExists
+		}
+		return nil, err
+	}
+	return account, nil
+}
+
+func (s *PostgresServiceAccountStore) GetServiceAccount(ctx context.Context, id int32) (*models.ServiceAccount, error) {
+	var account models.ServiceAccount
+	row := s.db.QueryRowContext(ctx, "SELECT id, name, description, created_at FROM service_accounts WHERE id = $1", id)
+	if err := row.Scan(&account.ID, &account.Name, &account.Description, &account.CreatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrServiceAccountNotFound
+		}
+		return nil, err
+	}
+	return &account, nil
+}
+
+func (s *PostgresServiceAccountStore) ListServiceAccounts(ctx context.Context) ([]models.ServiceAccount, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT id, name, description, created_at FROM service_accounts ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var accounts []models.ServiceAccount
+	for rows.Next() {
+		var account models.ServiceAccount
+		if err := rows.Scan(&account.ID, &account.Name, &account.Description, &account.CreatedAt); err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, account)
+	}
+	return accounts, rows.Err()
+}
+
+func (s *PostgresServiceAccountStore) CreateAPIKey(ctx context.Context, key *models.APIKey) error {
+	_, err := s.db.ExecContext(ctx, "INSERT INTO api_keys (id, service_account_id, name, key_hash, scopes, created_at) VALUES ($1, $2, $3, $4, $5, $6)",
+		key.ID, key.ServiceAccountID, key.Name, key.KeyHash, pq.Array(key.Scopes), key.CreatedAt)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == pqForeignKeyViolation {
+		return ErrServiceAccountNotFound
+	}
+	return err
+}
+
+func (s *PostgresServiceAccountStore) GetAPIKey(ctx context.Context, id string) (*models.APIKey, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+apiKeyColumns+" FROM api_keys WHERE id = $1", id)
+	key, err := scanAPIKey(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrAPIKeyNotFound
+	}
+	return key, err
+}
+
+func (s *PostgresServiceAccountStore) ListAPIKeys(ctx context.Context, serviceAccountID int32) ([]models.APIKey, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+apiKeyColumns+" FROM api_keys WHERE service_account_id = $1 ORDER BY created_at", serviceAccountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []models.APIKey
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, *key)
+	}
+	return keys, rows.Err()
+}
+
+func (s *PostgresServiceAccountStore) RevokeAPIKey(ctx context.Context, id string) error {
+	res, err := s.db.ExecContext(ctx, "UPDATE api_keys SET revoked_at = now() WHERE id = $1 AND revoked_at IS NULL", id)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+func (s *PostgresServiceAccountStore) TouchAPIKey(ctx context.Context, id string, at time.Time) error {
+	_, err := s.db.ExecContext(ctx, "UPDATE api_keys SET last_used_at = $2 WHERE id = $1", id, at)
+	return err
+}
+
+func scanAPIKey(row interface{ Scan(...interface{}) error }) (*models.APIKey, error) {
+	var key models.APIKey
+	err := row.Scan(&key.ID, &key.ServiceAccountID, &key.Name, &key.KeyHash, pq.Array(&key.Scopes), &key.CreatedAt, &key.LastUsedAt, &key.RevokedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
@@ -0,0 +1,38 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	models "fin-trans/models_package"
+)
+
+var (
+	ErrSessionNotFound   = errors.New("сессия не найдена")
+	ErrChallengeNotFound = errors.New("challenge не найден")
+)
+
+// SessionStore хранит живые сессии и ожидающие кода challenges. Общее хранилище нужно,
+// чтобы все реплики auth_service одинаково отвечали, действителен ли токен или код.
+type SessionStore interface {
+	// SaveSession создаёт или перезаписывает сессию; она удаляется после ExpiresAt
+	SaveSession(ctx context.Context, session *models.Session) error
+	// GetSession возвращает ErrSessionNotFound, если сессии нет или она истекла
+	GetSession(ctx context.Context, id string) (*models.Session, error)
+	// TouchSession обновляет время последней активности существующей сессии
+	TouchSession(ctx context.Context, id string, lastSeenAt time.Time) error
+	DeleteSession(ctx context.Context, id string) error
+	// ListUserSessions возвращает неистёкшие сессии пользователя в произвольном порядке
+	ListUserSessions(ctx context.Context, userID int32) ([]models.Session, error)
+
+	// SaveChallenge сохраняет challenge; он удаляется после ExpiresAt
+	SaveChallenge(ctx context.Context, challenge *models.AuthChallenge) error
+	// GetChallenge возвращает ErrChallengeNotFound, если challenge нет или он истёк
+	GetChallenge(ctx context.Context, id string) (*models.AuthChallenge, error)
+	// AddChallengeAttempt атомарно учитывает попытку ввода кода и возвращает число попыток
+	AddChallengeAttempt(ctx context.Context, id string) (int, error)
+	// DeleteChallenge удаляет challenge и сообщает, был ли он. Из параллельных верных
+	// вводов одноразового кода проходит только тот, что удалил challenge.
+	DeleteChallenge(ctx context.Context, id string) (bool, error)
+}
@@ -0,0 +1,146 @@
+package store
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	models "fin-trans/models_package"
+)
+
+// MemorySessionStore хранит сессии в памяти процесса.
+// Подходит для тестов и одной реплики: другие реплики этих сессий не видят.
+type MemorySessionStore struct {
+	mu         sync.Mutex
+	sessions   map[string]*models.Session
+	challenges map[string]*models.AuthChallenge
+}
+
+func NewMemorySessionStore() *MemorySessionStore {
+	return &MemorySessionStore{
+		sessions:   make(map[string]*models.Session),
+		challenges: make(map[string]*models.AuthChallenge),
+	}
+}
+
+func (s *MemorySessionStore) SaveSession(ctx context.Context, session *models.Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	copied := *session
+	s.sessions[session.ID] = &copied
+	return nil
+}
+
+func (s *MemorySessionStore) GetSession(ctx context.Context, id string) (*models.Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, exists := s.sessions[id]
+	if !exists {
+		return nil, ErrSessionNotFound
+	}
+	if session.ExpiresAt.Before(time.Now()) {
+		delete(s.sessions, id)
+		return nil, ErrSessionNotFound
+	}
+	copied := *session
+	return &copied, nil
+}
+
+func (s *MemorySessionStore) TouchSession(ctx context.Context, id string, lastSeenAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if session, exists := s.sessions[id]; exists {
+		session.LastSeenAt = lastSeenAt
+	}
+	return nil
+}
+
+func (s *MemorySessionStore) DeleteSession(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.sessions, id)
+	return nil
+}
+
+func (s *MemorySessionStore) ListUserSessions(ctx context.Context, userID int32) ([]models.Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var sessions []models.Session
+	now := time.Now()
+	for id, session := range s.sessions {
+		if session.ExpiresAt.Before(now) {
+			delete(s.sessions, id)
+			continue
+		}
+		if session.UserID == userID {
+			sessions = append(sessions, *session)
+		}
+	}
+	return sessions, nil
+}
+
+func (s *MemorySessionStore) SaveChallenge(ctx context.Context, challenge *models.AuthChallenge) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for id, existing := range s.challenges {
+		if existing.ExpiresAt.Before(now) {
+			delete(s.challenges, id)
+		}
+	}
+	copied := *challenge
+	s.challenges[challenge.ID] = &copied
+	return nil
+}
+
+func (s *MemorySessionStore) GetChallenge(ctx context.Context, id string) (*models.AuthChallenge, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	challenge, err := s.liveChallenge(id)
+	if err != nil {
+		return nil, err
+	}
+	copied := *challenge
+	return &copied, nil
+}
+
+func (s *MemorySessionStore) AddChallengeAttempt(ctx context.Context, id string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	challenge, err := s.liveChallenge(id)
+	if err != nil {
+		return 0, err
+	}
+	challenge.Attempts++
+	return challenge.Attempts, nil
+}
+
+func (s *MemorySessionStore) DeleteChallenge(ctx context.Context, id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, exists := s.challenges[id]
+	delete(s.challenges, id)
+	return exists, nil
+}
+
+// liveChallenge вызывается под s.mu
+func (s *MemorySessionStore) liveChallenge(id string) (*models.AuthChallenge, error) {
+	challenge, exists := s.challenges[id]
+	if !exists {
+		return nil, ErrChallengeNotFound
+	}
+	if challenge.ExpiresAt.Before(time.Now()) {
+		delete(s.challenges, id)
+		return nil, ErrChallengeNotFound
+	}
+	return challenge, nil
+}
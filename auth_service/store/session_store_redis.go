@@ -0,0 +1,229 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	models "fin-trans/models_package"
+)
+
+// RedisSessionStore хранит каждую сессию хешем session:<id> с EXPIREAT = ExpiresAt,
+// а ID сессий пользователя - множеством user_sessions:<user_id>.
+// Истёкшие ID из множества вычищаются при чтении. Challenges хранятся
+// хешами auth_challenge:<id> так же, с EXPIREAT = ExpiresAt.
+type RedisSessionStore struct {
+	rdb *redis.Client
+}
+
+func NewRedisSessionStore(rdb *redis.Client) *RedisSessionStore {
+	return &RedisSessionStore{rdb: rdb}
+}
+
+func sessionKey(id string) string {
+	return "session:" + id
+}
+
+func userSessionsKey(userID int32) string {
+	return fmt.Sprintf("user_sessions:%d", userID)
+}
+
+// touchScript обновляет last_seen_at, только если сессия ещё существует:
+// HSET по истёкшему ключу создал бы сессию без TTL
+var touchScript = redis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 1 then
+	return redis.call("HSET", KEYS[1], "last_seen_at", ARGV[1])
+end
+return 0`)
+
+func challengeKey(id string) string {
+	return "auth_challenge:" + id
+}
+
+// attemptScript увеличивает счётчик попыток, только если challenge ещё существует
+var attemptScript = redis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 1 then
+	return redis.call("HINCRBY", KEYS[1], "attempts", 1)
+end
+return -1`)
+
+func (s *RedisSessionStore) SaveSession(ctx context.Context, session *models.Session) error {
+	key := sessionKey(session.ID)
+	indexKey := userSessionsKey(session.UserID)
+
+	_, err := s.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key, map[string]interface{}{
+			"id":           session.ID,
+			"user_id":      session.UserID,
+			"username":     session.Username,
+			"token":        session.Token,
+			"device_name":  session.DeviceName,
+			"user_agent":   session.UserAgent,
+			"ip":           session.IP,
+			"created_at":   session.CreatedAt.UnixNano(),
+			"last_seen_at": session.LastSeenAt.UnixNano(),
+			"expires_at":   session.ExpiresAt.UnixNano(),
+		})
+		pipe.ExpireAt(ctx, key, session.ExpiresAt)
+		pipe.SAdd(ctx, indexKey, session.ID)
+		// Сохраняемая сессия продлена последней, значит истекает позже остальных
+		pipe.ExpireAt(ctx, indexKey, session.ExpiresAt)
+		return nil
+	})
+	return err
+}
+
+func (s *RedisSessionStore) GetSession(ctx context.Context, id string) (*models.Session, error) {
+	fields, err := s.rdb.HGetAll(ctx, sessionKey(id)).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, ErrSessionNotFound
+	}
+	return parseSession(fields)
+}
+
+func (s *RedisSessionStore) TouchSession(ctx context.Context, id string, lastSeenAt time.Time) error {
+	return touchScript.Run(ctx, s.rdb, []string{sessionKey(id)}, lastSeenAt.UnixNano()).Err()
+}
+
+func (s *RedisSessionStore) DeleteSession(ctx context.Context, id string) error {
+	userID, err := s.rdb.HGet(ctx, sessionKey(id), "user_id").Result()
+	if err == redis.Nil {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = s.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, sessionKey(id))
+		pipe.SRem(ctx, "user_sessions:"+userID, id)
+		return nil
+	})
+	return err
+}
+
+func (s *RedisSessionStore) ListUserSessions(ctx context.Context, userID int32) ([]models.Session, error) {
+	indexKey := userSessionsKey(userID)
+	ids, err := s.rdb.SMembers(ctx, indexKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	var sessions []models.Session
+	var stale []interface{}
+	for _, id := range ids {
+		session, err := s.GetSession(ctx, id)
+		if err == ErrSessionNotFound {
+			stale = append(stale, id)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, *session)
+	}
+	if len(stale) > 0 {
+		s.rdb.SRem(ctx, indexKey, stale...)
+	}
+	return sessions, nil
+}
+
+func (s *RedisSessionStore) SaveChallenge(ctx context.Context, challenge *models.AuthChallenge) error {
+	key := challengeKey(challenge.ID)
+	_, err := s.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key, map[string]interface{}{
+			"id":          challenge.ID,
+			"kind":        challenge.Kind,
+			"user_id":     challenge.UserID,
+			"device_name": challenge.DeviceName,
+			"code_hash":   challenge.CodeHash,
+			"attempts":    challenge.Attempts,
+			"expires_at":  challenge.ExpiresAt.UnixNano(),
+		})
+		pipe.ExpireAt(ctx, key, challenge.ExpiresAt)
+		return nil
+	})
+	return err
+}
+
+func (s *RedisSessionStore) GetChallenge(ctx context.Context, id string) (*models.AuthChallenge, error) {
+	fields, err := s.rdb.HGetAll(ctx, challengeKey(id)).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, ErrChallengeNotFound
+	}
+
+	userID, err := strconv.ParseInt(fields["user_id"], 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("повреждённый challenge: %w", err)
+	}
+	attempts, err := strconv.Atoi(fields["attempts"])
+	if err != nil {
+		return nil, fmt.Errorf("повреждённый challenge: attempts: %w", err)
+	}
+	expiresAt, err := strconv.ParseInt(fields["expires_at"], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("повреждённый challenge: expires_at: %w", err)
+	}
+	return &models.AuthChallenge{
+		ID:         fields["id"],
+		Kind:       fields["kind"],
+		UserID:     int32(userID),
+		DeviceName: fields["device_name"],
+		CodeHash:   fields["code_hash"],
+		Attempts:   attempts,
+		ExpiresAt:  time.Unix(0, expiresAt),
+	}, nil
+}
+
+func (s *RedisSessionStore) AddChallengeAttempt(ctx context.Context, id string) (int, error) {
+	attempts, err := attemptScript.Run(ctx, s.rdb, []string{challengeKey(id)}).Int()
+	if err != nil {
+		return 0, err
+	}
+	if attempts < 0 {
+		return 0, ErrChallengeNotFound
+	}
+	return attempts, nil
+}
+
+func (s *RedisSessionStore) DeleteChallenge(ctx context.Context, id string) (bool, error) {
+	n, err := s.rdb.Del(ctx, challengeKey(id)).Result()
+	return n > 0, err
+}
+
+func parseSession(fields map[string]string) (*models.Session, error) {
+	userID, err := strconv.ParseInt(fields["user_id"], 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("повреждённая сессия: %w", err)
+	}
+	session := &models.Session{
+		ID:         fields["id"],
+		UserID:     int32(userID),
+		Username:   fields["username"],
+		Token:      fields["token"],
+		DeviceName: fields["device_name"],
+		UserAgent:  fields["user_agent"],
+		IP:         fields["ip"],
+	}
+	for name, dst := range map[string]*time.Time{
+		"created_at":   &session.CreatedAt,
+		"last_seen_at": &session.LastSeenAt,
+		"expires_at":   &session.ExpiresAt,
+	} {
+		nanos, err := strconv.ParseInt(fields[name], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("повреждённая сессия: %s: %w", name, err)
+		}
+		*dst = time.Unix(0, nanos)
+	}
+	return session, nil
+}
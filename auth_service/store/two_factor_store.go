@@ -0,0 +1,26 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	models "fin-trans/models_package"
+)
+
+var ErrTOTPNotFound = errors.New("TOTP не настроен")
+
+// TwoFactorStore хранит секреты TOTP и коды восстановления
+type TwoFactorStore interface {
+	// SetPendingTOTP начинает (или начинает заново) подключение TOTP:
+	// сохраняет неподтверждённый секрет и заменяет коды восстановления
+	SetPendingTOTP(ctx context.Context, userID int32, secret string, recoveryCodeHashes []string) error
+	// GetTOTP возвращает ErrTOTPNotFound, если пользователь не подключал TOTP
+	GetTOTP(ctx context.Context, userID int32) (*models.UserTOTP, error)
+	EnableTOTP(ctx context.Context, userID int32) error
+	// AdvanceTOTPStep атомарно запоминает использованный шаг.
+	// Возвращает false, если этот или более поздний шаг уже использован.
+	AdvanceTOTPStep(ctx context.Context, userID int32, step int64) (bool, error)
+	ListRecoveryCodes(ctx context.Context, userID int32) ([]models.RecoveryCode, error)
+	// DeleteRecoveryCode возвращает false, если код уже удалён (использован)
+	DeleteRecoveryCode(ctx context.Context, id int64) (bool, error)
+}
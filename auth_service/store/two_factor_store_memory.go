@@ -0,0 +1,99 @@
+package store
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	models "fin-trans/models_package"
+)
+
+// MemoryTwoFactorStore хранит TOTP в памяти процесса
+type MemoryTwoFactorStore struct {
+	mu     sync.Mutex
+	nextID int64
+	totp   map[int32]*models.UserTOTP
+	codes  map[int64]models.RecoveryCode
+}
+
+func NewMemoryTwoFactorStore() *MemoryTwoFactorStore {
+	return &MemoryTwoFactorStore{
+		totp:  make(map[int32]*models.UserTOTP),
+		codes: make(map[int64]models.RecoveryCode),
+	}
+}
+
+func (s *MemoryTwoFactorStore) SetPendingTOTP(ctx context.Context, userID int32, secret string, recoveryCodeHashes []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.totp[userID] = &models.UserTOTP{UserID: userID, Secret: secret, CreatedAt: time.Now()}
+	for id, code := range s.codes {
+		if code.UserID == userID {
+			delete(s.codes, id)
+		}
+	}
+	for _, hash := range recoveryCodeHashes {
+		s.nextID++
+		s.codes[s.nextID] = models.RecoveryCode{ID: s.nextID, UserID: userID, CodeHash: hash}
+	}
+	return nil
+}
+
+func (s *MemoryTwoFactorStore) GetTOTP(ctx context.Context, userID int32) (*models.UserTOTP, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	totp, exists := s.totp[userID]
+	if !exists {
+		return nil, ErrTOTPNotFound
+	}
+	copied := *totp
+	return &copied, nil
+}
+
+func (s *MemoryTwoFactorStore) EnableTOTP(ctx context.Context, userID int32) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if totp, exists := s.totp[userID]; exists {
+		totp.Enabled = true
+	}
+	return nil
+}
+
+func (s *MemoryTwoFactorStore) AdvanceTOTPStep(ctx context.Context, userID int32, step int64) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	totp, exists := s.totp[userID]
+	if !exists || totp.LastStep >= step {
+		return false, nil
+	}
+	totp.LastStep = step
+	return true, nil
+}
+
+func (s *MemoryTwoFactorStore) ListRecoveryCodes(ctx context.Context, userID int32) ([]models.RecoveryCode, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var codes []models.RecoveryCode
+	for _, code := range s.codes {
+		if code.UserID == userID {
+			codes = append(codes, code)
+		}
+	}
+	return codes, nil
+}
+
+func (s *MemoryTwoFactorStore) DeleteRecoveryCode(ctx context.Context, id int64) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.codes[id]; !exists {
+		return false, nil
+	}
+	delete(s.codes, id)
+	return true, nil
+}
@@ -0,0 +1,122 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	models "fin-trans/models_package"
+)
+
+const twoFactorSchema = `
+CREATE TABLE IF NOT EXISTS user_totp (
+	user_id    INTEGER PRIMARY KEY REFERENCES users(id),
+	secret     TEXT NOT NULL,
+	enabled    BOOLEAN NOT NULL DEFAULT false,
+	last_step  BIGINT NOT NULL DEFAULT 0,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE TABLE IF NOT EXISTS user_recovery_codes (
+	id        BIGSERIAL PRIMARY KEY,
+	user_id   INTEGER NOT NULL REFERENCES users(id),
+	code_hash TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS user_recovery_codes_user_id_idx ON user_recovery_codes (user_id)`
+
+// PostgresTwoFactorStore хранит TOTP в таблицах user_totp и user_recovery_codes
+type PostgresTwoFactorStore struct {
+	db *sql.DB
+}
+
+func NewPostgresTwoFactorStore(db *sql.DB) (*PostgresTwoFactorStore, error) {
+	if _, err := db.Exec(twoFactorSchema); err != nil {
+		return nil, fmt.Errorf("не удалось создать таблицы TOTP: %w", err)
+	}
+	return &PostgresTwoFactorStore{db: db}, nil
+}
+
+func (s *PostgresTwoFactorStore) SetPendingTOTP(ctx context.Context, userID int32, secret string, recoveryCodeHashes []string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO user_totp (user_id, secret) VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE SET secret = EXCLUDED.secret, enabled = false, last_step = 0, created_at = now()`,
+		userID, secret)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM user_recovery_codes WHERE user_id = $1", userID); err != nil {
+		return err
+	}
+	for _, hash := range recoveryCodeHashes {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO user_recovery_codes (user_id, code_hash) VALUES ($1, $2)", userID, hash); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (s *PostgresTwoFactorStore) GetTOTP(ctx context.Context, userID int32) (*models.UserTOTP, error) {
+	var totp models.UserTOTP
+	row := s.db.QueryRowContext(ctx, "SELECT user_id, secret, enabled, last_step, created_at FROM user_totp WHERE user_id = $1", userID)
+	err := row.Scan(&totp.UserID, &totp.Secret, &totp.Enabled, &totp.LastStep, &totp.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrTOTPNotFound
+		}
+		return nil, err
+	}
+	return &totp, nil
+}
+
+func (s *PostgresTwoFactorStore) EnableTOTP(ctx context.Context, userID int32) error {
+	_, err := s.db.ExecContext(ctx, "UPDATE user_totp SET enabled = true WHERE user_id = $1", userID)
+	return err
+}
+
+func (s *PostgresTwoFactorStore) AdvanceTOTPStep(ctx context.Context, userID int32, step int64) (bool, error) {
+	res, err := s.db.ExecContext(ctx, "UPDATE user_totp SET last_step = $2 WHERE user_id = $1 AND last_step < $2", userID, step)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+func (s *PostgresTwoFactorStore) ListRecoveryCodes(ctx context.Context, userID int32) ([]models.RecoveryCode, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT id, user_id, code_hash FROM user_recovery_codes WHERE user_id = $1", userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var codes []models.RecoveryCode
+	for rows.Next() {
+		var code models.RecoveryCode
+		if err := rows.Scan(&code.ID, &code.UserID, &code.CodeHash); err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+	}
+	return codes, rows.Err()
+}
+
+func (s *PostgresTwoFactorStore) DeleteRecoveryCode(ctx context.Context, id int64) (bool, error) {
+	res, err := s.db.ExecContext(ctx, "DELETE FROM user_recovery_codes WHERE id = $1", id)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
@@ -0,0 +1,31 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	models "fin-trans/models_package"
+)
+
+var (
+	ErrUserExists   = errors.New("пользователь уже существует")
+	ErrUserNotFound = errors.New("пользователь не найден")
+)
+
+// UserStore хранит учётные записи пользователей сервиса аутентификации
+type UserStore interface {
+	// CreateUser сохраняет нового пользователя и возвращает его с присвоенным ID.
+	// Если имя занято, возвращает ErrUserExists.
+	CreateUser(ctx context.Context, username, passwordHash string) (*models.User, error)
+	// GetUserByUsername возвращает ErrUserNotFound, если пользователя нет
+	GetUserByUsername(ctx context.Context, username string) (*models.User, error)
+	// GetUserByID возвращает ErrUserNotFound, если пользователя нет
+	GetUserByID(ctx context.Context, id int32) (*models.User, error)
+	// UpdatePassword заменяет хеш пароля, возвращает ErrUserNotFound, если пользователя нет
+	UpdatePassword(ctx context.Context, id int32, passwordHash string) error
+	// ListUsers возвращает всех пользователей по возрастанию ID
+	ListUsers(ctx context.Context) ([]models.User, error)
+	// SetUserRole и SetUserStatus возвращают ErrUserNotFound, если пользователя нет
+	SetUserRole(ctx context.Context, id int32, role string) error
+	SetUserStatus(ctx context.Context, id int32, status string) error
+}
@@ -0,0 +1,122 @@
+package store
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	models "fin-trans/models_package"
+)
+
+// MemoryUserStore хранит пользователей в памяти процесса.
+// Используется в тестах и при локальной разработке без PostgreSQL.
+type MemoryUserStore struct {
+	mu     sync.RWMutex
+	nextID int32
+	byName map[string]*models.User
+	byID   map[int32]*models.User
+}
+
+func NewMemoryUserStore() *MemoryUserStore {
+	return &MemoryUserStore{
+		byName: make(map[string]*models.User),
+		byID:   make(map[int32]*models.User),
+	}
+}
+
+func (s *MemoryUserStore) CreateUser(ctx context.Context, username, passwordHash string) (*models.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.byName[username]; exists {
+		return nil, ErrUserExists
+	}
+
+	s.nextID++
+	user := &models.User{
+		ID:           s.nextID,
+		Username:     username,
+		PasswordHash: passwordHash,
+		CreatedAt:    time.Now(),
+		Status:       models.UserStatusActive,
+		Role:         models.RoleCustomer,
+	}
+	s.byName[username] = user
+	s.byID[user.ID] = user
+
+	copied := *user
+	return &copied, nil
+}
+
+func (s *MemoryUserStore) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	user, exists := s.byName[username]
+	if !exists {
+		return nil, ErrUserNotFound
+	}
+	copied := *user
+	return &copied, nil
+}
+
+func (s *MemoryUserStore) GetUserByID(ctx context.Context, id int32) (*models.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	user, exists := s.byID[id]
+	if !exists {
+		return nil, ErrUserNotFound
+	}
+	copied := *user
+	return &copied, nil
+}
+
+func (s *MemoryUserStore) UpdatePassword(ctx context.Context, id int32, passwordHash string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, exists := s.byID[id]
+	if !exists {
+		return ErrUserNotFound
+	}
+	user.PasswordHash = passwordHash
+	return nil
+}
+
+func (s *MemoryUserStore) ListUsers(ctx context.Context) ([]models.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	users := make([]models.User, 0, len(s.byID))
+	for _, user := range s.byID {
+		users = append(users, *user)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+	return users, nil
+}
+
+func (s *MemoryUserStore) SetUserRole(ctx context.Context, id int32, role string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, exists := s.byID[id]
+	if !exists {
+		return ErrUserNotFound
+	}
+	user.Role = role
+	return nil
+}
+
+func (s *MemoryUserStore) SetUserStatus(ctx context.Context, id int32, status string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, exists := s.byID[id]
+	if !exists {
+		return ErrUserNotFound
+	}
+	user.Status = status
+	return nil
+}
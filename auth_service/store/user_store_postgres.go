@@ -0,0 +1,133 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	models "fin-trans/models_package"
+)
+
+// Коды ошибок PostgreSQL
+const (
+	pqUniqueViolation     = "23505"
+	pqForeignKeyViolation = "23503"
+)
+
+const usersSchema = `
+CREATE TABLE IF NOT EXISTS users (
+	id            SERIAL PRIMARY KEY,
+	username      TEXT NOT NULL UNIQUE,
+	password_hash TEXT NOT NULL,
+	created_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+	status        TEXT NOT NULL DEFAULT 'active'
+);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role TEXT NOT NULL DEFAULT 'customer'`
+
+// PostgresUserStore хранит пользователей в таблице users.
+// id пользователя - это тот же user_id, что записывается в таблицу cards.
+type PostgresUserStore struct {
+	db *sql.DB
+}
+
+func NewPostgresUserStore(db *sql.DB) (*PostgresUserStore, error) {
+	if _, err := db.Exec(usersSchema); err != nil {
+		return nil, fmt.Errorf("не удалось создать таблицу users: %w", err)
+	}
+	return &PostgresUserStore{db: db}, nil
+}
+
+func (s *PostgresUserStore) CreateUser(ctx context.Context, username, passwordHash string) (*models.User, error) {
+	user := &models.User{
+		Username:     username,
+		PasswordHash: passwordHash,
+		Status:       models.UserStatusActive,
+		Role:         models.RoleCustomer,
+	}
+
+	row := s.db.QueryRowContext(ctx, "INSERT INTO users (username, password_hash, status, role) VALUES ($1, $2, $3, $4) RETURNING id, created_at",
+		user.Username, user.PasswordHash, user.Status, user.Role)
+	if err := row.Scan(&user.ID, &user.CreatedAt); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == pqUniqueViolation {
+			return nil, ErrUserExists
+		}
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (s *PostgresUserStore) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+userColumns+" FROM users WHERE username = $1", username)
+	return scanUser(row)
+}
+
+func (s *PostgresUserStore) GetUserByID(ctx context.Context, id int32) (*models.User, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+userColumns+" FROM users WHERE id = $1", id)
+	return scanUser(row)
+}
+
+func (s *PostgresUserStore) UpdatePassword(ctx context.Context, id int32, passwordHash string) error {
+	return s.updateUser(ctx, "UPDATE users SET password_hash = $2 WHERE id = $1", id, passwordHash)
+}
+
+func (s *PostgresUserStore) ListUsers(ctx context.Context) ([]models.User, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+userColumns+" FROM users ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []models.User
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, *user)
+	}
+	return users, rows.Err()
+}
+
+func (s *PostgresUserStore) SetUserRole(ctx context.Context, id int32, role string) error {
+	return s.updateUser(ctx, "UPDATE users SET role = $2 WHERE id = $1", id, role)
+}
+
+func (s *PostgresUserStore) SetUserStatus(ctx context.Context, id int32, status string) error {
+	return s.updateUser(ctx, "UPDATE users SET status = $2 WHERE id = $1", id, status)
+}
+
+// updateUser выполняет UPDATE одной строки users и возвращает ErrUserNotFound, если строки нет
+func (s *PostgresUserStore) updateUser(ctx context.Context, query string, args ...interface{}) error {
+	res, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+const userColumns = "id, username, password_hash, created_at, status, role"
+
+// scanUser читает строку с колонками userColumns из *sql.Row или *sql.Rows
+func scanUser(row interface{ Scan(...interface{}) error }) (*models.User, error) {
+	var user models.User
+	err := row.Scan(&user.ID, &user.Username, &user.PasswordHash, &user.CreatedAt, &user.Status, &user.Role)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return &user, nil
+}
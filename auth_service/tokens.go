@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	authpb "fin-trans/auth_service/proto"
+	"fin-trans/auth_service/store"
+	models "fin-trans/models_package"
+)
+
+// tokenPair - результат выдачи токенов при логине и ротации
+type tokenPair struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    int64 // время жизни access-токена в секундах
+}
+
+// issueTokens выпускает access-токен и refresh-токен сессии sessionID
+// и создаёт или продлевает саму сессию
+func (s *server) issueTokens(ctx context.Context, user *models.User, sessionID, deviceName string) (*tokenPair, error) {
+	accessToken, err := s.generateToken(user, sessionID, s.tokens.AccessTokenTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	err = s.refreshTokens.SaveRefreshToken(ctx, &models.RefreshToken{
+		TokenHash: hashToken(refreshToken),
+		FamilyID:  sessionID,
+		UserID:    user.ID,
+		Username:  user.Username,
+		IssuedAt:  now,
+		ExpiresAt: now.Add(s.tokens.RefreshTokenTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := s.sessions.GetSession(ctx, sessionID)
+	if errors.Is(err, store.ErrSessionNotFound) {
+		client := clientInfoFromContext(ctx)
+		session = &models.Session{
+			ID:         sessionID,
+			UserID:     user.ID,
+			Username:   user.Username,
+			DeviceName: deviceName,
+			UserAgent:  client.UserAgent,
+			IP:         client.IP,
+			CreatedAt:  now,
+		}
+	} else if err != nil {
+		return nil, err
+	}
+	session.Token = accessToken
+	session.LastSeenAt = now
+	session.ExpiresAt = now.Add(s.tokens.RefreshTokenTTL)
+	if err := s.sessions.SaveSession(ctx, session); err != nil {
+		return nil, err
+	}
+
+	return &tokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(s.tokens.AccessTokenTTL.Seconds()),
+	}, nil
+}
+
+// RefreshToken обменивает refresh-токен на новую пару токенов.
+// Каждый refresh-токен одноразовый: повторное предъявление уже использованного
+// токена означает его утечку, поэтому отзывается всё семейство.
+func (s *server) RefreshToken(ctx context.Context, req *authpb.RefreshTokenRequest) (*authpb.RefreshTokenResponse, error) {
+	tokenHash := hashToken(req.RefreshToken)
+
+	stored, err := s.refreshTokens.GetRefreshToken(ctx, tokenHash)
+	if errors.Is(err, store.ErrRefreshTokenNotFound) {
+		return nil, status.Error(codes.Unauthenticated, "Invalid refresh token")
+	}
+	if err != nil {
+		log.Printf("Ошибка при получении refresh-токена: %v", err)
+		return nil, status.Error(codes.Internal, "Could not load refresh token")
+	}
+
+	if stored.Revoked {
+		s.auditRefresh(ctx, stored, models.AuditOutcomeFailure, "token revoked")
+		return nil, status.Error(codes.Unauthenticated, "Refresh token revoked")
+	}
+	if stored.Rotated {
+		s.revokeFamily(ctx, stored)
+		return nil, status.Error(codes.Unauthenticated, "Refresh token reuse detected")
+	}
+	if stored.ExpiresAt.Before(time.Now()) {
+		return nil, status.Error(codes.Unauthenticated, "Refresh token expired")
+	}
+
+	// Два одновременных запроса с одним токеном: выигрывает только первый
+	rotated, err := s.refreshTokens.MarkRefreshTokenRotated(ctx, tokenHash)
+	if err != nil {
+		log.Printf("Ошибка при ротации refresh-токена: %v", err)
+		return nil, status.Error(codes.Internal, "Could not rotate refresh token")
+	}
+	if !rotated {
+		s.revokeFamily(ctx, stored)
+		return nil, status.Error(codes.Unauthenticated, "Refresh token reuse detected")
+	}
+
+	user, err := s.users.GetUserByID(ctx, stored.UserID)
+	if err != nil {
+		log.Printf("Ошибка при получении пользователя %d: %v", stored.UserID, err)
+		return nil, status.Error(codes.Unauthenticated, "Invalid refresh token")
+	}
+	if user.Status != models.UserStatusActive {
+		s.auditRefresh(ctx, stored, models.AuditOutcomeFailure, "user "+user.Status)
+		return nil, status.Error(codes.PermissionDenied, "User is not active")
+	}
+
+	tokens, err := s.issueTokens(ctx, user, stored.FamilyID, "")
+	if err != nil {
+		log.Printf("Ошибка при выпуске токенов: %v", err)
+		return nil, status.Error(codes.Internal, "Could not generate token")
+	}
+
+	s.auditRefresh(ctx, stored, models.AuditOutcomeSuccess, "")
+	return &authpb.RefreshTokenResponse{
+		Success:      true,
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    tokens.ExpiresIn,
+	}, nil
+}
+
+// revokeFamily отзывает семейство токенов и завершает сессию пользователя
+func (s *server) revokeFamily(ctx context.Context, token *models.RefreshToken) {
+	log.Printf("Повторное использование refresh-токена пользователя %s, семейство %s отозвано", token.Username, token.FamilyID)
+	if err := s.refreshTokens.RevokeRefreshTokenFamily(ctx, token.FamilyID); err != nil {
+		log.Printf("Ошибка при отзыве семейства refresh-токенов: %v", err)
+	}
+
+	if err := s.sessions.DeleteSession(ctx, token.FamilyID); err != nil {
+		log.Printf("Ошибка при удалении сессии: %v", err)
+	}
+	s.auditRefresh(ctx, token, models.AuditOutcomeFailure, "token reuse detected")
+}
+
+func (s *server) auditRefresh(ctx context.Context, token *models.RefreshToken, outcome, detail string) {
+	if detail == "" {
+		detail = "session " + token.FamilyID
+	} else {
+		detail += ", session " + token.FamilyID
+	}
+	s.audit(ctx, models.AuditEvent{Event: auditRefresh, UserID: token.UserID, Username: token.Username, Outcome: outcome, Detail: detail})
+}
+
+// randomToken возвращает случайную строку для непрозрачных токенов
+func randomToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
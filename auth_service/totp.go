@@ -0,0 +1,380 @@
+package main
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	authint "fin-trans/auth_interceptor_package"
+	authpb "fin-trans/auth_service/proto"
+	"fin-trans/auth_service/store"
+	models "fin-trans/models_package"
+)
+
+// Параметры TOTP (RFC 6238) - значения по умолчанию, которые понимают все приложения-аутентификаторы
+const (
+	totpPeriod      = 30
+	totpDigits      = 6
+	totpSecretBytes = 20
+	totpSkew        = 1 // допускаем соседние шаги из-за расхождения часов
+
+	recoveryCodeCount  = 10
+	recoveryCodeLength = 10
+	// После стольких неверных кодов challenge удаляется и нужно войти заново
+	maxChallengeAttempts = 5
+)
+
+var base32NoPadding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// EnrollTOTP начинает подключение TOTP: выдаёт секрет и коды восстановления.
+// 2FA включается только после ConfirmTOTP, до этого вход работает как раньше.
+func (s *server) EnrollTOTP(ctx context.Context, req *authpb.EnrollTOTPRequest) (*authpb.EnrollTOTPResponse, error) {
+	caller, err := authint.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.twoFactor.GetTOTP(ctx, caller.UserID)
+	if err != nil && !errors.Is(err, store.ErrTOTPNotFound) {
+		log.Printf("Ошибка при получении TOTP: %v", err)
+		return nil, status.Error(codes.Internal, "Could not load two-factor settings")
+	}
+	if existing != nil && existing.Enabled {
+		return nil, status.Error(codes.FailedPrecondition, "Two-factor authentication is already enabled")
+	}
+
+	secret := make([]byte, totpSecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, status.Error(codes.Internal, "Could not generate secret")
+	}
+	encodedSecret := base32NoPadding.EncodeToString(secret)
+
+	// Коды показываются пользователю один раз, храним только bcrypt-хеши
+	codesPlain := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		code, err := randomRecoveryCode()
+		if err != nil {
+			return nil, status.Error(codes.Internal, "Could not generate recovery codes")
+		}
+		hash, err := bcrypt.GenerateFromPassword([]byte(normalizeRecoveryCode(code)), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "Could not hash recovery codes")
+		}
+		codesPlain = append(codesPlain, code)
+		hashes = append(hashes, string(hash))
+	}
+
+	if err := s.twoFactor.SetPendingTOTP(ctx, caller.UserID, encodedSecret, hashes); err != nil {
+		log.Printf("Ошибка при сохранении TOTP: %v", err)
+		return nil, status.Error(codes.Internal, "Could not save two-factor settings")
+	}
+
+	s.auditCaller(ctx, auditTOTPEnroll, models.AuditOutcomeSuccess, "")
+	return &authpb.EnrollTOTPResponse{
+		OtpauthUri:    totpURI(s.totpCfg.Issuer, caller.Username, encodedSecret),
+		Secret:        encodedSecret,
+		RecoveryCodes: codesPlain,
+	}, nil
+}
+
+// ConfirmTOTP включает 2FA, если пользователь ввёл верный код из приложения
+func (s *server) ConfirmTOTP(ctx context.Context, req *authpb.ConfirmTOTPRequest) (*authpb.ConfirmTOTPResponse, error) {
+	caller, err := authint.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	totp, err := s.twoFactor.GetTOTP(ctx, caller.UserID)
+	if errors.Is(err, store.ErrTOTPNotFound) {
+		return nil, status.Error(codes.FailedPrecondition, "TOTP enrollment has not been started")
+	}
+	if err != nil {
+		log.Printf("Ошибка при получении TOTP: %v", err)
+		return nil, status.Error(codes.Internal, "Could not load two-factor settings")
+	}
+	if totp.Enabled {
+		return nil, status.Error(codes.FailedPrecondition, "Two-factor authentication is already enabled")
+	}
+	// Подбор кода подтверждения ограничивается так же, как вход
+	client := clientInfoFromContext(ctx)
+	if err := s.limiter.check(ctx, caller.Username, client.IP); err != nil {
+		s.auditCaller(ctx, auditTOTPConfirm, models.AuditOutcomeLocked, "")
+		return nil, err
+	}
+
+	ok, err := s.checkTOTP(ctx, totp, req.Code)
+	if err != nil {
+		log.Printf("Ошибка при проверке TOTP: %v", err)
+		return nil, status.Error(codes.Internal, "Could not verify code")
+	}
+	if !ok {
+		s.limiter.fail(ctx, caller.Username, client.IP)
+		s.auditCaller(ctx, auditTOTPConfirm, models.AuditOutcomeFailure, "invalid code")
+		return &authpb.ConfirmTOTPResponse{Success: false, Message: "Invalid code"}, nil
+	}
+
+	if err := s.twoFactor.EnableTOTP(ctx, caller.UserID); err != nil {
+		log.Printf("Ошибка при включении TOTP: %v", err)
+		return nil, status.Error(codes.Internal, "Could not enable two-factor authentication")
+	}
+	s.auditCaller(ctx, auditTOTPConfirm, models.AuditOutcomeSuccess, "")
+	return &authpb.ConfirmTOTPResponse{Success: true, Message: "Two-factor authentication enabled"}, nil
+}
+
+// VerifySecondFactor завершает вход по challenge из Login: принимает код TOTP
+// или одноразовый код восстановления и выдаёт токены
+func (s *server) VerifySecondFactor(ctx context.Context, req *authpb.VerifySecondFactorRequest) (*authpb.LoginResponse, error) {
+	challenge, ok := s.takeChallengeAttempt(ctx, req.ChallengeId)
+	if !ok {
+		s.audit(ctx, models.AuditEvent{Event: auditSecondFactor, Outcome: models.AuditOutcomeFailure, Detail: "invalid or expired challenge"})
+		return nil, status.Error(codes.Unauthenticated, "Invalid or expired challenge")
+	}
+
+	user, err := s.users.GetUserByID(ctx, challenge.UserID)
+	if err != nil && !errors.Is(err, store.ErrUserNotFound) {
+		log.Printf("Ошибка при получении пользователя: %v", err)
+		return nil, status.Error(codes.Internal, "Could not load user")
+	}
+	if user == nil || user.Status != models.UserStatusActive {
+		s.deleteChallenge(ctx, req.ChallengeId)
+		return nil, status.Error(codes.Unauthenticated, "Invalid or expired challenge")
+	}
+	// Неверные коды считаются вместе с неудачными входами по имени и IP
+	client := clientInfoFromContext(ctx)
+	if err := s.limiter.check(ctx, user.Username, client.IP); err != nil {
+		s.audit(ctx, models.AuditEvent{Event: auditSecondFactor, UserID: user.ID, Username: user.Username, Outcome: models.AuditOutcomeLocked})
+		return nil, err
+	}
+
+	verified, err := s.checkSecondFactor(ctx, user.ID, req.Code)
+	if err != nil {
+		log.Printf("Ошибка при проверке второго фактора: %v", err)
+		return nil, status.Error(codes.Internal, "Could not verify code")
+	}
+	if !verified {
+		s.limiter.fail(ctx, user.Username, client.IP)
+		s.audit(ctx, models.AuditEvent{Event: auditSecondFactor, UserID: user.ID, Username: user.Username, Outcome: models.AuditOutcomeFailure, Detail: "invalid code"})
+		return &authpb.LoginResponse{Success: false, SecondFactorRequired: true, ChallengeId: req.ChallengeId}, nil
+	}
+	// Тот же код, параллельно введённый на другой реплике, второй сессии не откроет
+	if !s.deleteChallenge(ctx, req.ChallengeId) {
+		s.audit(ctx, models.AuditEvent{Event: auditSecondFactor, UserID: user.ID, Username: user.Username, Outcome: models.AuditOutcomeFailure, Detail: "challenge already used"})
+		return nil, status.Error(codes.Unauthenticated, "Invalid or expired challenge")
+	}
+	s.limiter.success(ctx, user.Username)
+
+	sessionID, err := randomToken()
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Could not generate token")
+	}
+	tokens, err := s.issueTokens(ctx, user, sessionID, challenge.DeviceName)
+	if err != nil {
+		log.Printf("Ошибка при выпуске токенов: %v", err)
+		return nil, status.Error(codes.Internal, "Could not generate token")
+	}
+
+	s.audit(ctx, models.AuditEvent{Event: auditSecondFactor, UserID: user.ID, Username: user.Username, Outcome: models.AuditOutcomeSuccess, Detail: "session " + sessionID})
+	return &authpb.LoginResponse{
+		Success:      true,
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    tokens.ExpiresIn,
+	}, nil
+}
+
+// twoFactorEnabled сообщает, нужен ли пользователю второй фактор при входе
+func (s *server) twoFactorEnabled(ctx context.Context, userID int32) (bool, error) {
+	totp, err := s.twoFactor.GetTOTP(ctx, userID)
+	if errors.Is(err, store.ErrTOTPNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return totp.Enabled, nil
+}
+
+// newLoginChallenge запоминает вход, ожидающий второй фактор
+func (s *server) newLoginChallenge(ctx context.Context, user *models.User, deviceName string) (string, error) {
+	id, err := randomToken()
+	if err != nil {
+		return "", err
+	}
+
+	err = s.sessions.SaveChallenge(ctx, &models.AuthChallenge{
+		ID:         id,
+		Kind:       models.AuthChallengeLogin,
+		UserID:     user.ID,
+		DeviceName: deviceName,
+		ExpiresAt:  time.Now().Add(s.totpCfg.ChallengeTTL),
+	})
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// takeChallengeAttempt учитывает попытку ввода кода. Challenge с исчерпанными
+// попытками удаляется, чтобы код нельзя было подобрать перебором.
+func (s *server) takeChallengeAttempt(ctx context.Context, id string) (models.AuthChallenge, bool) {
+	challenge, err := s.sessions.GetChallenge(ctx, id)
+	if err != nil {
+		if !errors.Is(err, store.ErrChallengeNotFound) {
+			log.Printf("Ошибка при получении challenge: %v", err)
+		}
+		return models.AuthChallenge{}, false
+	}
+	if challenge.Kind != models.AuthChallengeLogin {
+		return models.AuthChallenge{}, false
+	}
+	if time.Now().After(challenge.ExpiresAt) {
+		s.deleteChallenge(ctx, id)
+		return models.AuthChallenge{}, false
+	}
+
+	attempts, err := s.sessions.AddChallengeAttempt(ctx, id)
+	if err != nil {
+		if !errors.Is(err, store.ErrChallengeNotFound) {
+			log.Printf("Ошибка при учёте попытки ввода кода: %v", err)
+		}
+		return models.AuthChallenge{}, false
+	}
+	if attempts > maxChallengeAttempts {
+		s.deleteChallenge(ctx, id)
+		return models.AuthChallenge{}, false
+	}
+	challenge.Attempts = attempts
+	return *challenge, true
+}
+
+// deleteChallenge удаляет challenge и сообщает, был ли он ещё не использован
+func (s *server) deleteChallenge(ctx context.Context, id string) bool {
+	deleted, err := s.sessions.DeleteChallenge(ctx, id)
+	if err != nil {
+		log.Printf("Ошибка при удалении challenge: %v", err)
+		return false
+	}
+	return deleted
+}
+
+// checkSecondFactor проверяет код TOTP, а код другой длины - как код восстановления
+func (s *server) checkSecondFactor(ctx context.Context, userID int32, code string) (bool, error) {
+	code = strings.TrimSpace(code)
+	if len(code) == totpDigits {
+		totp, err := s.twoFactor.GetTOTP(ctx, userID)
+		if err != nil {
+			return false, err
+		}
+		return s.checkTOTP(ctx, totp, code)
+	}
+	return s.useRecoveryCode(ctx, userID, code)
+}
+
+// checkTOTP проверяет код и запоминает его шаг, чтобы один код нельзя было использовать дважды
+func (s *server) checkTOTP(ctx context.Context, totp *models.UserTOTP, code string) (bool, error) {
+	step, ok := verifyTOTP(totp.Secret, strings.TrimSpace(code), time.Now())
+	if !ok {
+		return false, nil
+	}
+	return s.twoFactor.AdvanceTOTPStep(ctx, totp.UserID, step)
+}
+
+// useRecoveryCode сверяет код с сохранёнными хешами и удаляет совпавший
+func (s *server) useRecoveryCode(ctx context.Context, userID int32, code string) (bool, error) {
+	normalized := normalizeRecoveryCode(code)
+	if len(normalized) != recoveryCodeLength {
+		return false, nil
+	}
+
+	stored, err := s.twoFactor.ListRecoveryCodes(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	for _, rc := range stored {
+		if bcrypt.CompareHashAndPassword([]byte(rc.CodeHash), []byte(normalized)) == nil {
+			// false - код только что использовал параллельный запрос
+			return s.twoFactor.DeleteRecoveryCode(ctx, rc.ID)
+		}
+	}
+	return false, nil
+}
+
+// verifyTOTP возвращает шаг времени, для которого совпал код
+func verifyTOTP(secret, code string, now time.Time) (int64, bool) {
+	key, err := base32NoPadding.DecodeString(secret)
+	if err != nil || len(code) != totpDigits {
+		return 0, false
+	}
+
+	current := now.Unix() / totpPeriod
+	for delta := int64(-totpSkew); delta <= totpSkew; delta++ {
+		step := current + delta
+		if subtle.ConstantTimeCompare([]byte(totpCode(key, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// totpCode - HOTP (RFC 4226) для номера шага
+func totpCode(key []byte, step int64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", totpDigits, value%1000000)
+}
+
+// totpURI формирует otpauth://-ссылку для QR-кода приложения-аутентификатора
+func totpURI(issuer, username, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(totpDigits))
+	query.Set("period", fmt.Sprint(totpPeriod))
+
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + username,
+		RawQuery: query.Encode(),
+	}
+	return u.String()
+}
+
+// randomRecoveryCode возвращает код вида "abcde-fghij"
+func randomRecoveryCode() (string, error) {
+	b := make([]byte, recoveryCodeLength*5/8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	code := strings.ToLower(base32NoPadding.EncodeToString(b))
+	return code[:recoveryCodeLength/2] + "-" + code[recoveryCodeLength/2:], nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	code = strings.ReplaceAll(code, "-", "")
+	return strings.ReplaceAll(code, " ", "")
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	authint "fin-trans/auth_interceptor_package"
+	authpb "fin-trans/auth_service/proto"
+	"fin-trans/auth_service/store"
+	models "fin-trans/models_package"
+)
+
+// Секрет из тестовых векторов RFC 6238 (SHA1)
+var rfc6238Key = []byte("12345678901234567890")
+
+func TestTOTPCodeRFC6238(t *testing.T) {
+	// В RFC коды из 8 цифр, у нас 6 - это последние 6 цифр того же значения
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},          // 94287082
+		{1111111109, "081804"},  // 07081804
+		{1111111111, "050471"},  // 14050471
+		{1234567890, "005924"},  // 89005924
+		{2000000000, "279037"},  // 69279037
+		{20000000000, "353130"}, // 65353130
+	}
+	for _, tt := range tests {
+		if got := totpCode(rfc6238Key, tt.unix/totpPeriod); got != tt.want {
+			t.Errorf("totpCode(T=%d) = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestVerifyTOTPWindow(t *testing.T) {
+	secret := base32NoPadding.EncodeToString(rfc6238Key)
+	now := time.Unix(1111111111, 0) // шаг 37037037
+	current := now.Unix() / totpPeriod
+	tests := []struct {
+		name string
+		code string
+		step int64
+		ok   bool
+	}{
+		{"current step", totpCode(rfc6238Key, current), current, true},
+		{"previous step", totpCode(rfc6238Key, current-1), current - 1, true},
+		{"next step", totpCode(rfc6238Key, current+1), current + 1, true},
+		{"two steps behind", totpCode(rfc6238Key, current-2), 0, false},
+		{"two steps ahead", totpCode(rfc6238Key, current+2), 0, false},
+		{"wrong code", "000000", 0, false},
+		{"too short", totpCode(rfc6238Key, current)[:5], 0, false},
+		{"eight digits", "14050471", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := verifyTOTP(secret, tt.code, now)
+			if ok != tt.ok || step != tt.step {
+				t.Fatalf("verifyTOTP(%q) = %d, %v, want %d, %v", tt.code, step, ok, tt.step, tt.ok)
+			}
+		})
+	}
+	if _, ok := verifyTOTP("not base32!", totpCode(rfc6238Key, current), now); ok {
+		t.Fatalf("verifyTOTP() accepted a code for a malformed secret")
+	}
+}
+
+func TestCheckTOTPRejectsReplay(t *testing.T) {
+	ctx := context.Background()
+	twoFactor := store.NewMemoryTwoFactorStore()
+	s := &server{twoFactor: twoFactor}
+	if err := twoFactor.SetPendingTOTP(ctx, 1, base32NoPadding.EncodeToString(rfc6238Key), nil); err != nil {
+		t.Fatal(err)
+	}
+	totp, err := twoFactor.GetTOTP(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	current := time.Now().Unix() / totpPeriod
+	steps := []struct {
+		name string
+		step int64
+		ok   bool
+	}{
+		{"previous step", current - 1, true},
+		{"same code again", current - 1, false},
+		{"current step", current, true},
+		{"older step after newer", current - 1, false},
+		{"current step again", current, false},
+	}
+	for _, tt := range steps {
+		ok, err := s.checkTOTP(ctx, totp, totpCode(rfc6238Key, tt.step))
+		if err != nil || ok != tt.ok {
+			t.Fatalf("%s: checkTOTP() = %v, %v, want %v", tt.name, ok, err, tt.ok)
+		}
+	}
+}
+
+func TestRecoveryCodeSingleUse(t *testing.T) {
+	ctx := context.Background()
+	twoFactor := store.NewMemoryTwoFactorStore()
+	s := &server{twoFactor: twoFactor}
+
+	code, err := randomRecoveryCode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(normalizeRecoveryCode(code)), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := twoFactor.SetPendingTOTP(ctx, 1, base32NoPadding.EncodeToString(rfc6238Key), []string{string(hash)}); err != nil {
+		t.Fatal(err)
+	}
+
+	attempts := []struct {
+		name, code string
+		ok         bool
+	}{
+		{"wrong code", "aaaaa-aaaaa", false},
+		{"upper case with spaces", strings.ToUpper(strings.Replace(code, "-", " ", 1)), true},
+		{"used code", code, false},
+	}
+	for _, tt := range attempts {
+		ok, err := s.checkSecondFactor(ctx, 1, tt.code)
+		if err != nil || ok != tt.ok {
+			t.Fatalf("%s: checkSecondFactor() = %v, %v, want %v", tt.name, ok, err, tt.ok)
+		}
+	}
+}
+
+func newTwoFactorTestServer(t *testing.T) (*server, *models.User) {
+	t.Helper()
+	ctx := context.Background()
+	s := &server{
+		users:     store.NewMemoryUserStore(),
+		sessions:  store.NewMemorySessionStore(),
+		twoFactor: store.NewMemoryTwoFactorStore(),
+		auditLog:  store.NewMemoryAuditStore(),
+		limiter:   newLoginLimiter(lockoutConfig{UserThreshold: 3, IPThreshold: 20, BaseDelay: time.Minute, MaxDelay: time.Hour, ResetAfter: time.Hour}, store.NewMemoryLockoutStore()),
+		totpCfg:   twoFactorConfig{ChallengeTTL: time.Minute},
+	}
+	user, err := s.users.CreateUser(ctx, "alice", "unused")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.twoFactor.SetPendingTOTP(ctx, user.ID, base32NoPadding.EncodeToString(rfc6238Key), nil); err != nil {
+		t.Fatal(err)
+	}
+	return s, user
+}
+
+// wrongTOTPCode - код, не совпадающий ни с одним кодом рядом с текущим шагом
+func wrongTOTPCode() string {
+	current := time.Now().Unix() / totpPeriod
+	valid := make(map[string]bool)
+	for step := current - 2; step <= current+2; step++ {
+		valid[totpCode(rfc6238Key, step)] = true
+	}
+	for n := 0; ; n++ {
+		if code := fmt.Sprintf("%06d", n); !valid[code] {
+			return code
+		}
+	}
+}
+
+func TestConfirmTOTPLockout(t *testing.T) {
+	s, user := newTwoFactorTestServer(t)
+	ctx := authint.NewContext(context.Background(), &authint.Identity{UserID: user.ID, Username: user.Username})
+
+	for i := 0; i < 3; i++ {
+		res, err := s.ConfirmTOTP(ctx, &authpb.ConfirmTOTPRequest{Code: wrongTOTPCode()})
+		if err != nil || res.Success {
+			t.Fatalf("attempt %d: ConfirmTOTP() = %v, %v, want unsuccessful response", i+1, res, err)
+		}
+	}
+	// Даже верный код после порога не принимается, пока действует блокировка
+	code := totpCode(rfc6238Key, time.Now().Unix()/totpPeriod)
+	if _, err := s.ConfirmTOTP(ctx, &authpb.ConfirmTOTPRequest{Code: code}); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("ConfirmTOTP() after lockout = %v, want ResourceExhausted", err)
+	}
+}
+
+// Новый challenge не даёт новых попыток: неверные коды копятся в счётчике входов
+func TestVerifySecondFactorLockout(t *testing.T) {
+	s, user := newTwoFactorTestServer(t)
+	ctx := context.Background()
+	if err := s.twoFactor.EnableTOTP(ctx, user.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		challengeID, err := s.newLoginChallenge(ctx, user, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := s.VerifySecondFactor(ctx, &authpb.VerifySecondFactorRequest{ChallengeId: challengeID, Code: wrongTOTPCode()})
+		if err != nil || res.Success {
+			t.Fatalf("attempt %d: VerifySecondFactor() = %v, %v, want unsuccessful response", i+1, res, err)
+		}
+	}
+	challengeID, err := s.newLoginChallenge(ctx, user, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	code := totpCode(rfc6238Key, time.Now().Unix()/totpPeriod)
+	_, err = s.VerifySecondFactor(ctx, &authpb.VerifySecondFactorRequest{ChallengeId: challengeID, Code: code})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("VerifySecondFactor() after lockout = %v, want ResourceExhausted", err)
+	}
+	if err := s.limiter.check(ctx, user.Username, ""); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("limiter.check() = %v, want the user to stay locked out", err)
+	}
+}
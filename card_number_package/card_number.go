@@ -0,0 +1,193 @@
+// Package cardnumber выпускает и проверяет номера карт: номер начинается с BIN
+// из диапазона, настроенного для типа карты, и заканчивается контрольной цифрой Луна.
+package cardnumber
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Длина номеров, которые принимаются на вход. Выпускаются номера длины Length.
+const (
+	MinLength = 12
+	MaxLength = 19
+	Length    = 16
+)
+
+// RangesEnv - диапазоны BIN по типам карт, например "debit=400000-400999;credit=510000-510999,520000"
+const RangesEnv = "CARDS_BIN_RANGES"
+
+// DefaultRanges используются, если RangesEnv не задана
+const DefaultRanges = "debit=400000-400999;credit=510000-510999"
+
+var (
+	ErrInvalid         = errors.New("неверный номер карты")
+	ErrUnknownCardType = errors.New("неизвестный тип карты")
+)
+
+// Validate проверяет, что номер состоит из цифр допустимой длины и сходится контрольная цифра.
+// Так проверяются только номера, выпущенные Generator: карты, выпущенные до него,
+// контрольной цифры Луна не имеют, и поиск по номеру проверяет их ValidateFormat.
+func Validate(number string) error {
+	if err := ValidateFormat(number); err != nil {
+		return err
+	}
+	if CheckDigit(number[:len(number)-1]) != number[len(number)-1] {
+		return ErrInvalid
+	}
+	return nil
+}
+
+// ValidateFormat проверяет только, что номер состоит из цифр допустимой длины
+func ValidateFormat(number string) error {
+	if len(number) < MinLength || len(number) > MaxLength {
+		return ErrInvalid
+	}
+	for _, r := range number {
+		if r < '0' || r > '9' {
+			return ErrInvalid
+		}
+	}
+	return nil
+}
+
+// CheckDigit - контрольная цифра Луна для номера без неё. payload должен состоять из цифр.
+func CheckDigit(payload string) byte {
+	sum := 0
+	// Удваивается каждая вторая цифра, начиная с последней цифры payload
+	for i := len(payload) - 1; i >= 0; i -= 2 {
+		d := int(payload[i]-'0') * 2
+		if d > 9 {
+			d -= 9
+		}
+		sum += d
+		if i > 0 {
+			sum += int(payload[i-1] - '0')
+		}
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+// BINRange - диапазон префиксов одинаковой длины, границы включаются
+type BINRange struct {
+	From, To uint64
+}
+
+// Generator выпускает номера по диапазонам BIN типов карт
+type Generator struct {
+	ranges map[string][]BINRange
+}
+
+// FromEnv - генератор по RangesEnv или DefaultRanges
+func FromEnv() (*Generator, error) {
+	spec := os.Getenv(RangesEnv)
+	if spec == "" {
+		spec = DefaultRanges
+	}
+	return NewGenerator(spec)
+}
+
+// NewGenerator разбирает описание вида "тип=BIN[-BIN][,...];тип=...".
+// Типы карт не зависят от регистра.
+func NewGenerator(spec string) (*Generator, error) {
+	g := &Generator{ranges: make(map[string][]BINRange)}
+	for _, entry := range strings.Split(spec, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		cardType, list, ok := strings.Cut(entry, "=")
+		cardType = strings.ToLower(strings.TrimSpace(cardType))
+		if !ok || cardType == "" {
+			return nil, fmt.Errorf("неверное описание диапазонов BIN %q", entry)
+		}
+		for _, item := range strings.Split(list, ",") {
+			r, err := parseRange(strings.TrimSpace(item))
+			if err != nil {
+				return nil, fmt.Errorf("тип карты %s: %w", cardType, err)
+			}
+			g.ranges[cardType] = append(g.ranges[cardType], r)
+		}
+	}
+	if len(g.ranges) == 0 {
+		return nil, errors.New("не задано ни одного диапазона BIN")
+	}
+	return g, nil
+}
+
+func parseRange(item string) (BINRange, error) {
+	fromStr, toStr, isRange := strings.Cut(item, "-")
+	if !isRange {
+		toStr = fromStr
+	}
+	if len(fromStr) != len(toStr) || len(fromStr) < 6 || len(fromStr) > 8 || fromStr[0] == '0' {
+		return BINRange{}, fmt.Errorf("неверный BIN %q: нужно 6-8 цифр, не начиная с 0", item)
+	}
+	from, err := strconv.ParseUint(fromStr, 10, 64)
+	if err != nil {
+		return BINRange{}, fmt.Errorf("неверный BIN %q", item)
+	}
+	to, err := strconv.ParseUint(toStr, 10, 64)
+	if err != nil || to < from {
+		return BINRange{}, fmt.Errorf("неверный BIN %q", item)
+	}
+	return BINRange{From: from, To: to}, nil
+}
+
+// Supports - настроены ли диапазоны для типа карты
+func (g *Generator) Supports(cardType string) bool {
+	_, ok := g.ranges[strings.ToLower(cardType)]
+	return ok
+}
+
+// Generate выпускает случайный номер типа cardType. Уникальность номера
+// проверяет вызывающий, при совпадении достаточно вызвать Generate ещё раз.
+func (g *Generator) Generate(cardType string) (string, error) {
+	ranges, ok := g.ranges[strings.ToLower(cardType)]
+	if !ok {
+		return "", ErrUnknownCardType
+	}
+
+	// Диапазон выбирается пропорционально числу BIN в нём
+	var total uint64
+	for _, r := range ranges {
+		total += r.To - r.From + 1
+	}
+	n, err := randUint64(total)
+	if err != nil {
+		return "", err
+	}
+	var r BINRange
+	for _, r = range ranges {
+		if size := r.To - r.From + 1; n >= size {
+			n -= size
+			continue
+		}
+		break
+	}
+
+	var b strings.Builder
+	b.WriteString(strconv.FormatUint(r.From+n, 10))
+	for b.Len() < Length-1 {
+		d, err := randUint64(10)
+		if err != nil {
+			return "", err
+		}
+		b.WriteByte(byte('0' + d))
+	}
+	payload := b.String()
+	return payload + string(CheckDigit(payload)), nil
+}
+
+func randUint64(max uint64) (uint64, error) {
+	n, err := rand.Int(rand.Reader, new(big.Int).SetUint64(max))
+	if err != nil {
+		return 0, err
+	}
+	return n.Uint64(), nil
+}
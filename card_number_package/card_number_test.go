@@ -0,0 +1,136 @@
+package cardnumber
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestCheckDigit(t *testing.T) {
+	tests := []struct {
+		payload string
+		want    byte
+	}{
+		{"7992739871", '3'},
+		{"411111111111111", '1'},
+		{"401288888888188", '1'},
+		{"555555555555444", '4'},
+		{"37828224631000", '5'},
+		{"601111111111111", '7'},
+		{"000000000000000", '0'},
+	}
+	for _, tt := range tests {
+		if got := CheckDigit(tt.payload); got != tt.want {
+			t.Errorf("CheckDigit(%q) = %c, want %c", tt.payload, got, tt.want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		number string
+		valid  bool
+	}{
+		{"4111111111111111", true},
+		{"4012888888881881", true},
+		{"5555555555554444", true},
+		{"378282246310005", true},
+		{"6011111111111117", true},
+		{"4111111111111112", false},
+		{"4111111111111121", false},
+		{"411111111111111a", false},
+		{"4111 1111 1111 1111", false},
+		{"79927398713", false}, // контрольная цифра сходится, но номер короче MinLength
+		{"", false},
+		{"41111111111111111111", false},
+	}
+	for _, tt := range tests {
+		err := Validate(tt.number)
+		if (err == nil) != tt.valid {
+			t.Errorf("Validate(%q) = %v, want valid %v", tt.number, err, tt.valid)
+		}
+	}
+}
+
+func TestValidateFormat(t *testing.T) {
+	tests := []struct {
+		number string
+		valid  bool
+	}{
+		{"4111111111111111", true},
+		{"4111111111111112", true}, // номер старого формата без контрольной цифры Луна
+		{"0000000000004217", true},
+		{"411111111111", true},
+		{"4111111111111111111", true},
+		{"41111111111", false},
+		{"41111111111111111111", false},
+		{"411111111111111a", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		err := ValidateFormat(tt.number)
+		if (err == nil) != tt.valid {
+			t.Errorf("ValidateFormat(%q) = %v, want valid %v", tt.number, err, tt.valid)
+		}
+	}
+}
+
+func TestNewGeneratorRejectsBadSpecs(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"debit",
+		"=400000",
+		"debit=40000",
+		"debit=040000",
+		"debit=400999-400000",
+		"debit=400000-4000000",
+		"debit=400000-abcdef",
+	} {
+		if _, err := NewGenerator(spec); err == nil {
+			t.Errorf("NewGenerator(%q) succeeded, want error", spec)
+		}
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	g, err := NewGenerator("Debit=400000-400002; credit=51000000,520000-520000")
+	if err != nil {
+		t.Fatalf("NewGenerator: %v", err)
+	}
+	if !g.Supports("DEBIT") || !g.Supports("credit") || g.Supports("prepaid") {
+		t.Fatalf("Supports: unexpected card types")
+	}
+	if _, err := g.Generate("prepaid"); err != ErrUnknownCardType {
+		t.Fatalf("Generate(prepaid) = %v, want ErrUnknownCardType", err)
+	}
+
+	tests := []struct {
+		cardType string
+		inRange  func(number string) bool
+	}{
+		{"debit", func(n string) bool {
+			bin, _ := strconv.Atoi(n[:6])
+			return bin >= 400000 && bin <= 400002
+		}},
+		{"credit", func(n string) bool {
+			return strings.HasPrefix(n, "51000000") || strings.HasPrefix(n, "520000")
+		}},
+	}
+	for _, tt := range tests {
+		for i := 0; i < 200; i++ {
+			number, err := g.Generate(tt.cardType)
+			if err != nil {
+				t.Fatalf("Generate(%s): %v", tt.cardType, err)
+			}
+			if len(number) != Length {
+				t.Fatalf("Generate(%s) = %q, want %d digits", tt.cardType, number, Length)
+			}
+			if err := Validate(number); err != nil {
+				t.Fatalf("Generate(%s) = %q, fails Validate: %v", tt.cardType, number, err)
+			}
+			if !tt.inRange(number) {
+				t.Fatalf("Generate(%s) = %q, BIN outside configured ranges", tt.cardType, number)
+			}
+		}
+	}
+}
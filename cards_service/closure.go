@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"time"
+
+	authint "fin-trans/auth_interceptor_package"
+	usfl "fin-trans/database_methods_package"
+	models "fin-trans/models_package"
+	cardpb "fin-trans/proto/proto_generated/cards_service"
+	"fin-trans/transactions_service/history"
+	"fin-trans/transactions_service/pending"
+
+	"github.com/lib/pq"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// closingCard - строка cards, заблокированная на время закрытия
+type closingCard struct {
+	userID   int32
+	balance  float64
+	currency string
+	status   string
+}
+
+// DeleteCard закрывает карту, не удаляя её: история переводов остаётся доступной.
+// Остаток переводится на другую открытую карту того же владельца, card_id - номер карты.
+func (s *server) DeleteCard(ctx context.Context, req *cardpb.DeleteCardRequest) (*cardpb.DeleteCardResponse, error) {
+	caller, err := authint.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.Reason == "" {
+		return nil, status.Error(codes.InvalidArgument, "reason is required")
+	}
+	if err := validateCardNumber(req.CardId); err != nil {
+		return nil, err
+	}
+	if req.TransferToCardNumber != "" {
+		if err := validateCardNumber(req.TransferToCardNumber); err != nil {
+			return nil, err
+		}
+	}
+	if req.TransferToCardNumber == req.CardId {
+		return nil, status.Error(codes.InvalidArgument, "cannot transfer the balance to the card being closed")
+	}
+
+	tx, err := usfl.DB.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("Ошибка при начале транзакции: %v", err)
+		return nil, status.Error(codes.Internal, "could not close card")
+	}
+	defer tx.Rollback()
+
+	cards, err := lockCards(ctx, tx, req.CardId, req.TransferToCardNumber)
+	if err != nil {
+		log.Printf("Ошибка при получении карты %s: %v", req.CardId, err)
+		return nil, status.Error(codes.Internal, "could not close card")
+	}
+
+	// Чужая карта выглядит так же, как несуществующая. Закрывает карту только владелец:
+	// операторам карты клиентов доступны на просмотр и блокировку.
+	card, ok := cards[req.CardId]
+	if !ok || !caller.CanAccessUser(card.userID) {
+		return &cardpb.DeleteCardResponse{Success: false, Message: "Card not found"}, nil
+	}
+	if !canTransition(card.status, models.CardStatusClosed) {
+		return &cardpb.DeleteCardResponse{Success: false, Message: "Card is already closed"}, nil
+	}
+
+	// Переводы, стоящие в очереди или ждущие кода, после закрытия не смогли бы пройти.
+	// Проверка после блокировки строки карты, чтобы не пропустить перевод, принятый сейчас.
+	inFlight, err := pending.InFlight(ctx, tx, req.CardId)
+	if err != nil {
+		log.Printf("Ошибка при проверке незавершённых переводов по карте %s: %v", req.CardId, err)
+		return nil, status.Error(codes.Internal, "could not check pending transactions")
+	}
+	if inFlight > 0 {
+		return &cardpb.DeleteCardResponse{Success: false, Message: "Card has transactions in progress, try again later"}, nil
+	}
+	if card.balance < 0 {
+		return &cardpb.DeleteCardResponse{Success: false, Message: "Card has a negative balance"}, nil
+	}
+
+	var transferred float64
+	if card.balance > 0 {
+		if req.TransferToCardNumber == "" {
+			return &cardpb.DeleteCardResponse{Success: false, Message: "Card balance is not zero, specify a card to transfer it to"}, nil
+		}
+		target, ok := cards[req.TransferToCardNumber]
+		if !ok || target.userID != card.userID {
+			return &cardpb.DeleteCardResponse{Success: false, Message: "Transfer card must belong to the same owner"}, nil
+		}
+		if target.status != models.CardStatusActive {
+			return &cardpb.DeleteCardResponse{Success: false, Message: "Transfer card is not active"}, nil
+		}
+		if target.currency != card.currency {
+			return &cardpb.DeleteCardResponse{Success: false, Message: "Transfer card must be in the same currency"}, nil
+		}
+
+		transferred = card.balance
+		if _, err := tx.ExecContext(ctx, "UPDATE cards SET balance = balance + $1 WHERE card_number = $2", transferred, req.TransferToCardNumber); err != nil {
+			log.Printf("Ошибка при переводе остатка с карты %s: %v", req.CardId, err)
+			return nil, status.Error(codes.Internal, "could not transfer balance")
+		}
+		// Перевод остатка виден в истории обеих карт
+		remainder := models.FintransSuccessfulTransactionsPostgres{
+			CardNumber:          req.CardId,
+			RecipientCardNumber: req.TransferToCardNumber,
+			Amount:              transferred,
+			Currency:            card.currency,
+		}
+		if err := history.Record(ctx, tx, remainder); err != nil {
+			log.Printf("Ошибка при сохранении перевода остатка с карты %s: %v", req.CardId, err)
+			return nil, status.Error(codes.Internal, "could not transfer balance")
+		}
+	}
+
+	closedAt := time.Now().UTC()
+	if _, err := tx.ExecContext(ctx, "UPDATE cards SET balance = 0, closed_at = $2, close_reason = $3 WHERE card_number = $1",
+		req.CardId, closedAt, req.Reason); err != nil {
+		log.Printf("Ошибка при закрытии карты %s: %v", req.CardId, err)
+		return nil, status.Error(codes.Internal, "could not close card")
+	}
+	if err := recordCardStatus(ctx, tx, req.CardId, card.status, models.CardStatusClosed, models.CardReasonCustomerRequest, statusActor(caller), closedAt); err != nil {
+		log.Printf("Ошибка при закрытии карты %s: %v", req.CardId, err)
+		return nil, status.Error(codes.Internal, "could not close card")
+	}
+	if err := tx.Commit(); err != nil {
+		log.Printf("Ошибка при подтверждении закрытия карты %s: %v", req.CardId, err)
+		return nil, status.Error(codes.Internal, "could not close card")
+	}
+
+	log.Printf("Карта %s закрыта пользователем %s: %s", req.CardId, caller.Username, req.Reason)
+	return &cardpb.DeleteCardResponse{
+		Success:           true,
+		Message:           "Card closed",
+		TransferredAmount: transferred,
+		ClosedAt:          closedAt.Format(time.RFC3339),
+		Currency:          card.currency,
+	}, nil
+}
+
+// lockCards блокирует строки карт до конца транзакции. Строки берутся в порядке
+// номеров, чтобы два встречных закрытия не ждали друг друга.
+func lockCards(ctx context.Context, tx *sql.Tx, numbers ...string) (map[string]closingCard, error) {
+	rows, err := tx.QueryContext(ctx, "SELECT card_number, user_id, balance, currency, status FROM cards WHERE card_number = ANY($1) ORDER BY card_number FOR UPDATE",
+		pq.Array(numbers))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cards := make(map[string]closingCard)
+	for rows.Next() {
+		var number string
+		var card closingCard
+		if err := rows.Scan(&number, &card.userID, &card.balance, &card.currency, &card.status); err != nil {
+			return nil, err
+		}
+		cards[number] = card
+	}
+	return cards, rows.Err()
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+// recipientCheckConfig - сколько проверок получателя вызывающий может сделать за Window.
+// Ограничение не даёт перебором номеров собрать имена держателей карт.
+type recipientCheckConfig struct {
+	Limit  int
+	Window time.Duration
+}
+
+func loadRecipientCheckConfig() recipientCheckConfig {
+	return recipientCheckConfig{
+		Limit:  envInt("CARDS_RECIPIENT_CHECK_LIMIT", 20),
+		Window: envDuration("CARDS_RECIPIENT_CHECK_WINDOW", time.Minute),
+	}
+}
+
+// expiryConfig - срок действия карт и задача, которая их просрочивает
+type expiryConfig struct {
+	ValidityYears int           // на сколько лет выпускается карта
+	CheckInterval time.Duration // как часто искать истёкшие карты
+	NoticeBefore  time.Duration // за сколько до истечения предупредить владельца
+}
+
+func loadExpiryConfig() expiryConfig {
+	return expiryConfig{
+		ValidityYears: envInt("CARDS_VALIDITY_YEARS", 5),
+		CheckInterval: envDuration("CARDS_EXPIRY_CHECK_INTERVAL", time.Hour),
+		NoticeBefore:  envDuration("CARDS_EXPIRY_NOTICE_BEFORE", 30*24*time.Hour),
+	}
+}
+
+// envInt читает положительное число из переменной окружения
+func envInt(name string, def int) int {
+	value := os.Getenv(name)
+	if value == "" {
+		return def
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		log.Printf("Неверное значение %s=%q, используется %d", name, value, def)
+		return def
+	}
+	return n
+}
+
+// envDuration читает длительность вида "15m" из переменной окружения
+func envDuration(name string, def time.Duration) time.Duration {
+	value := os.Getenv(name)
+	if value == "" {
+		return def
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		log.Printf("Неверное значение %s=%q, используется %v", name, value, def)
+		return def
+	}
+	return d
+}
@@ -0,0 +1,253 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	authint "fin-trans/auth_interceptor_package"
+	usfl "fin-trans/database_methods_package"
+	models "fin-trans/models_package"
+	notifier "fin-trans/notifier_package"
+	cardpb "fin-trans/proto/proto_generated/cards_service"
+	"fin-trans/transactions_service/history"
+	"fin-trans/transactions_service/limits"
+	"fin-trans/transactions_service/pending"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// expiryActor - автор смены статуса, сделанной задачей истечения сроков
+const expiryActor = "system"
+
+// monthIndex - номер месяца от начала эры. Карта со сроком month/year истекла
+// к моменту t, если её monthIndex меньше monthIndex(t).
+func monthIndex(year int, month time.Month) int {
+	return year*12 + int(month)
+}
+
+// runExpiry периодически переводит истёкшие карты в EXPIRED и заранее
+// предупреждает владельцев карт, срок которых скоро истечёт
+func (s *server) runExpiry() {
+	ticker := time.NewTicker(s.expiry.CheckInterval)
+	defer ticker.Stop()
+	for ; ; <-ticker.C {
+		ctx := context.Background()
+		now := time.Now().UTC()
+		if n, err := s.expireCards(ctx, now); err != nil {
+			log.Printf("Ошибка при истечении сроков карт: %v", err)
+		} else if n > 0 {
+			log.Printf("Истёк срок карт: %d", n)
+		}
+		if err := s.notifyExpiringCards(ctx, now); err != nil {
+			log.Printf("Ошибка при уведомлении об истечении сроков карт: %v", err)
+		}
+	}
+}
+
+// expireCards переводит в EXPIRED карты, срок которых закончился к now
+func (s *server) expireCards(ctx context.Context, now time.Time) (int, error) {
+	numbers, err := queryCardNumbers(ctx, "SELECT card_number FROM cards WHERE status IN ($1, $2, $3) AND expiry_year * 12 + expiry_month < $4",
+		models.CardStatusActive, models.CardStatusFrozen, models.CardStatusBlocked, monthIndex(now.Year(), now.Month()))
+	if err != nil {
+		return 0, err
+	}
+
+	anyone := func(int32) bool { return true }
+	expired := 0
+	for _, number := range numbers {
+		_, err := setCardStatus(ctx, number, models.CardStatusExpired, models.CardReasonExpired, expiryActor, anyone)
+		switch {
+		case err == nil:
+			expired++
+		case err == errTransition:
+			// Карту успели закрыть или перевыпустить
+		default:
+			log.Printf("Ошибка при истечении срока карты %s: %v", number, err)
+		}
+	}
+	return expired, nil
+}
+
+// notifyExpiringCards предупреждает владельцев карт, срок которых истекает
+// в пределах NoticeBefore. Каждая карта отмечается один раз, даже если
+// реплик сервиса несколько.
+func (s *server) notifyExpiringCards(ctx context.Context, now time.Time) error {
+	horizon := now.Add(s.expiry.NoticeBefore)
+	rows, err := usfl.DB.QueryContext(ctx, `SELECT card_number, username, expiry_month, expiry_year FROM cards
+		WHERE status IN ($1, $2) AND expiry_notified_at IS NULL AND expiry_year * 12 + expiry_month < $3`,
+		models.CardStatusActive, models.CardStatusFrozen, monthIndex(horizon.Year(), horizon.Month()))
+	if err != nil {
+		return err
+	}
+	var cards []models.Card
+	for rows.Next() {
+		var card models.Card
+		if err := rows.Scan(&card.CardNumber, &card.Username, &card.ExpiryMonth, &card.ExpiryYear); err != nil {
+			rows.Close()
+			return err
+		}
+		cards = append(cards, card)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, card := range cards {
+		res, err := usfl.DB.ExecContext(ctx, "UPDATE cards SET expiry_notified_at = $2 WHERE card_number = $1 AND expiry_notified_at IS NULL", card.CardNumber, now)
+		if err != nil {
+			return err
+		}
+		if n, err := res.RowsAffected(); err != nil || n == 0 {
+			continue
+		}
+		msg := notifier.Message{
+			Recipient: card.Username,
+			Subject:   "Card expiring soon",
+			Body: fmt.Sprintf("Your card ending in %s expires at the end of %02d/%d. Request a reissue to keep using it: the balance will be moved to the new card.",
+				card.CardNumber[len(card.CardNumber)-4:], card.ExpiryMonth, card.ExpiryYear),
+		}
+		if err := s.notifier.Notify(ctx, msg); err != nil {
+			log.Printf("Ошибка при отправке уведомления об истечении срока карты %s: %v", card.CardNumber, err)
+		}
+	}
+	return nil
+}
+
+func queryCardNumbers(ctx context.Context, query string, args ...any) ([]string, error) {
+	rows, err := usfl.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var numbers []string
+	for rows.Next() {
+		var number string
+		if err := rows.Scan(&number); err != nil {
+			return nil, err
+		}
+		numbers = append(numbers, number)
+	}
+	return numbers, rows.Err()
+}
+
+// ReissueCard выпускает взамен карты новую с новым номером и сроком. Баланс
+// переходит на новую карту, старая закрывается, карты ссылаются друг на друга.
+// Карту перевыпускает владелец, заблокированную банком - только сервисный аккаунт
+// со scope cards:write, например система поддержки.
+func (s *server) ReissueCard(ctx context.Context, req *cardpb.ReissueCardRequest) (*cardpb.ReissueCardResponse, error) {
+	caller, err := authint.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateCardNumber(req.CardNumber); err != nil {
+		return nil, err
+	}
+
+	tx, err := usfl.DB.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("Ошибка при начале транзакции: %v", err)
+		return nil, status.Error(codes.Internal, "could not reissue card")
+	}
+	defer tx.Rollback()
+
+	var old models.Card
+	err = tx.QueryRowContext(ctx, "SELECT user_id, card_type, username, balance, currency, status FROM cards WHERE card_number = $1 FOR UPDATE", req.CardNumber).
+		Scan(&old.UserID, &old.CardType, &old.Username, &old.Balance, &old.Currency, &old.Status)
+	// Чужая карта выглядит так же, как несуществующая. Перевыпускает карту владелец,
+	// операторам карты клиентов доступны на просмотр и блокировку.
+	if err == sql.ErrNoRows || (err == nil && !caller.CanAccessUser(old.UserID)) {
+		return &cardpb.ReissueCardResponse{Success: false, Message: "Card not found"}, nil
+	}
+	if err != nil {
+		log.Printf("Ошибка при получении карты %s: %v", req.CardNumber, err)
+		return nil, status.Error(codes.Internal, "could not reissue card")
+	}
+	if !canTransition(old.Status, models.CardStatusClosed) {
+		return &cardpb.ReissueCardResponse{Success: false, Message: "Card is closed"}, nil
+	}
+	// Проверка после блокировки строки карты, как в DeleteCard
+	inFlight, err := pending.InFlight(ctx, tx, req.CardNumber)
+	if err != nil {
+		log.Printf("Ошибка при проверке незавершённых переводов по карте %s: %v", req.CardNumber, err)
+		return nil, status.Error(codes.Internal, "could not check pending transactions")
+	}
+	if inFlight > 0 {
+		return &cardpb.ReissueCardResponse{Success: false, Message: "Card has transactions in progress, try again later"}, nil
+	}
+	if old.Status == models.CardStatusBlocked && !caller.IsServiceAccount() {
+		return &cardpb.ReissueCardResponse{Success: false, Message: "Card is blocked by the bank, contact support"}, nil
+	}
+	if !s.numbers.Supports(old.CardType) {
+		return nil, status.Errorf(codes.FailedPrecondition, "cards of type %s are no longer issued", old.CardType)
+	}
+
+	newCard := models.Card{
+		UserID:   old.UserID,
+		CardType: old.CardType,
+		Status:   models.CardStatusActive,
+		Username: old.Username,
+		Currency: old.Currency,
+		Replaces: req.CardNumber,
+	}
+	newCard.ExpiryMonth, newCard.ExpiryYear = s.newCardExpiry(time.Now())
+	if err := s.insertCard(ctx, tx, &newCard); err != nil {
+		log.Printf("Ошибка при выпуске карты взамен %s: %v", req.CardNumber, err)
+		return nil, err
+	}
+
+	// Лимиты и потраченное по ним переходят на новую карту: limits.Used считает расходы
+	// по цепочке перевыпусков, собственные лимиты копируются
+	if err := limits.CopyToReissued(ctx, tx, req.CardNumber, newCard.CardNumber, statusActor(caller), time.Now().UTC()); err != nil {
+		log.Printf("Ошибка при переносе лимитов с карты %s: %v", req.CardNumber, err)
+		return nil, status.Error(codes.Internal, "could not reissue card")
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE cards SET balance = $2 WHERE card_number = $1", newCard.CardNumber, old.Balance); err != nil {
+		log.Printf("Ошибка при переносе баланса с карты %s: %v", req.CardNumber, err)
+		return nil, status.Error(codes.Internal, "could not reissue card")
+	}
+	// Перенос остатка виден в истории обеих карт
+	if old.Balance > 0 {
+		carried := models.FintransSuccessfulTransactionsPostgres{
+			CardNumber:          req.CardNumber,
+			RecipientCardNumber: newCard.CardNumber,
+			Amount:              old.Balance,
+			Currency:            old.Currency,
+		}
+		if err := history.Record(ctx, tx, carried); err != nil {
+			log.Printf("Ошибка при сохранении переноса баланса с карты %s: %v", req.CardNumber, err)
+			return nil, status.Error(codes.Internal, "could not reissue card")
+		}
+	}
+
+	closedAt := time.Now().UTC()
+	if _, err := tx.ExecContext(ctx, "UPDATE cards SET balance = 0, replaced_by = $2, closed_at = $3, close_reason = $4 WHERE card_number = $1",
+		req.CardNumber, newCard.CardNumber, closedAt, "reissued as "+newCard.CardNumber); err != nil {
+		log.Printf("Ошибка при закрытии перевыпущенной карты %s: %v", req.CardNumber, err)
+		return nil, status.Error(codes.Internal, "could not reissue card")
+	}
+	if err := recordCardStatus(ctx, tx, req.CardNumber, old.Status, models.CardStatusClosed, models.CardReasonReissued, statusActor(caller), closedAt); err != nil {
+		log.Printf("Ошибка при закрытии перевыпущенной карты %s: %v", req.CardNumber, err)
+		return nil, status.Error(codes.Internal, "could not reissue card")
+	}
+	if err := tx.Commit(); err != nil {
+		log.Printf("Ошибка при подтверждении перевыпуска карты %s: %v", req.CardNumber, err)
+		return nil, status.Error(codes.Internal, "could not reissue card")
+	}
+
+	log.Printf("Карта %s перевыпущена как %s пользователем %s", req.CardNumber, newCard.CardNumber, caller.Username)
+	return &cardpb.ReissueCardResponse{
+		Success:           true,
+		Message:           "Card reissued",
+		CardNumber:        newCard.CardNumber,
+		ExpiryMonth:       int32(newCard.ExpiryMonth),
+		ExpiryYear:        int32(newCard.ExpiryYear),
+		TransferredAmount: old.Balance,
+		Currency:          old.Currency,
+	}, nil
+}
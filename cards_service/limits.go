@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"time"
+
+	authint "fin-trans/auth_interceptor_package"
+	usfl "fin-trans/database_methods_package"
+	models "fin-trans/models_package"
+	cardpb "fin-trans/proto/proto_generated/cards_service"
+	"fin-trans/transactions_service/limits"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func limitsToProto(l limits.Limits) *cardpb.CardLimits {
+	return &cardpb.CardLimits{
+		PerTransaction: l.PerTransaction,
+		Daily:          l.Daily,
+		Monthly:        l.Monthly,
+		DailyCount:     int32(l.DailyCount),
+	}
+}
+
+// GetCardLimits возвращает лимиты карты и сколько по ним уже потрачено
+func (s *server) GetCardLimits(ctx context.Context, req *cardpb.GetCardLimitsRequest) (*cardpb.GetCardLimitsResponse, error) {
+	caller, err := authint.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateCardNumber(req.CardNumber); err != nil {
+		return nil, err
+	}
+
+	var userID int32
+	var currency string
+	err = usfl.DB.QueryRowContext(ctx, "SELECT user_id, currency FROM cards WHERE card_number = $1", req.CardNumber).Scan(&userID, &currency)
+	// Чужая карта выглядит так же, как несуществующая
+	if err == sql.ErrNoRows || (err == nil && !caller.CanViewUser(userID)) {
+		return &cardpb.GetCardLimitsResponse{Success: false, Message: "Card not found"}, nil
+	}
+	if err != nil {
+		log.Printf("Ошибка при получении карты %s: %v", req.CardNumber, err)
+		return nil, status.Error(codes.Internal, "could not load card limits")
+	}
+
+	l, custom, err := limits.Load(ctx, usfl.DB, req.CardNumber)
+	if err != nil {
+		log.Printf("Ошибка при получении лимитов карты %s: %v", req.CardNumber, err)
+		return nil, status.Error(codes.Internal, "could not load card limits")
+	}
+	used, err := limits.Used(ctx, usfl.DB, req.CardNumber, time.Now())
+	if err != nil {
+		log.Printf("Ошибка при подсчёте расходов по карте %s: %v", req.CardNumber, err)
+		return nil, status.Error(codes.Internal, "could not load card limits")
+	}
+
+	return &cardpb.GetCardLimitsResponse{
+		Success:           true,
+		Limits:            limitsToProto(l),
+		IsDefault:         !custom,
+		UsedToday:         used.Daily,
+		UsedThisMonth:     used.Monthly,
+		TransactionsToday: int32(used.DailyCount),
+		Currency:          currency,
+	}, nil
+}
+
+// canRaiseLimits - может ли вызывающий менять лимиты чужих карт и поднимать их выше
+// значений по умолчанию для типа карты: операторы и сервисные аккаунты со scope
+// cards:write. Такие изменения отдельно пишутся в журнал.
+func canRaiseLimits(caller *authint.Identity) bool {
+	return caller.HasRole(models.RoleOperator) || caller.IsServiceAccount()
+}
+
+// SetCardLimits задаёт карте собственные лимиты. Нулевое поле запроса оставляет
+// лимит прежним. Владелец карты может только снизить лимиты относительно значений
+// по умолчанию для её типа, поднять их выше может оператор.
+func (s *server) SetCardLimits(ctx context.Context, req *cardpb.SetCardLimitsRequest) (*cardpb.SetCardLimitsResponse, error) {
+	caller, err := authint.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateCardNumber(req.CardNumber); err != nil {
+		return nil, err
+	}
+	if req.PerTransaction < 0 || req.Daily < 0 || req.Monthly < 0 || req.DailyCount < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limits must not be negative")
+	}
+
+	tx, err := usfl.DB.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("Ошибка при начале транзакции: %v", err)
+		return nil, status.Error(codes.Internal, "could not set card limits")
+	}
+	defer tx.Rollback()
+
+	var userID int32
+	var cardType, cardStatus, currency string
+	err = tx.QueryRowContext(ctx, "SELECT user_id, card_type, status, currency FROM cards WHERE card_number = $1 FOR UPDATE", req.CardNumber).
+		Scan(&userID, &cardType, &cardStatus, &currency)
+	// Владелец меняет лимиты своей карты, остальные - только по canRaiseLimits
+	privileged := canRaiseLimits(caller)
+	if err == sql.ErrNoRows || (err == nil && !caller.CanAccessUser(userID) && !privileged) {
+		return &cardpb.SetCardLimitsResponse{Success: false, Message: "Card not found"}, nil
+	}
+	if err != nil {
+		log.Printf("Ошибка при получении карты %s: %v", req.CardNumber, err)
+		return nil, status.Error(codes.Internal, "could not set card limits")
+	}
+	if cardStatus == models.CardStatusClosed {
+		return &cardpb.SetCardLimitsResponse{Success: false, Message: "Card is closed"}, nil
+	}
+
+	l, _, err := limits.Load(ctx, tx, req.CardNumber)
+	if err != nil {
+		log.Printf("Ошибка при получении лимитов карты %s: %v", req.CardNumber, err)
+		return nil, status.Error(codes.Internal, "could not set card limits")
+	}
+	if req.PerTransaction > 0 {
+		l.PerTransaction = req.PerTransaction
+	}
+	if req.Daily > 0 {
+		l.Daily = req.Daily
+	}
+	if req.Monthly > 0 {
+		l.Monthly = req.Monthly
+	}
+	if req.DailyCount > 0 {
+		l.DailyCount = int(req.DailyCount)
+	}
+	if err := l.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "per-transaction limit must not exceed the daily limit and the daily limit must not exceed the monthly one")
+	}
+	aboveDefaults := !l.Within(limits.ForType(cardType, currency))
+	if aboveDefaults && !privileged {
+		return nil, status.Error(codes.PermissionDenied, "only an operator can raise limits above the card type defaults")
+	}
+
+	if err := limits.Save(ctx, tx, req.CardNumber, l, statusActor(caller), time.Now().UTC()); err != nil {
+		log.Printf("Ошибка при сохранении лимитов карты %s: %v", req.CardNumber, err)
+		return nil, status.Error(codes.Internal, "could not set card limits")
+	}
+	if err := tx.Commit(); err != nil {
+		log.Printf("Ошибка при сохранении лимитов карты %s: %v", req.CardNumber, err)
+		return nil, status.Error(codes.Internal, "could not set card limits")
+	}
+
+	if aboveDefaults || !caller.CanAccessUser(userID) {
+		log.Printf("Лимиты карты %s пользователя %d изменены %s (выше значений по умолчанию: %t): %+v",
+			req.CardNumber, userID, statusActor(caller), aboveDefaults, l)
+	} else {
+		log.Printf("Лимиты карты %s изменены пользователем %s: %+v", req.CardNumber, caller.Username, l)
+	}
+	return &cardpb.SetCardLimitsResponse{Success: true, Message: "Card limits updated", Limits: limitsToProto(l)}, nil
+}
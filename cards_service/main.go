@@ -5,68 +5,185 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
-	"math/rand"
 	"net"
+	"os"
 	"sync"
 	"time"
 
+	authint "fin-trans/auth_interceptor_package"
+	authpb "fin-trans/auth_service/proto"
+	cardnumber "fin-trans/card_number_package"
 	usfl "fin-trans/database_methods_package"
 	models "fin-trans/models_package"
+	notifier "fin-trans/notifier_package"
 	cardpb "fin-trans/proto/proto_generated/cards_service"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 )
 
+// cardsPolicy - кто может вызывать методы CardService.
+// Клиенты работают со своими картами (владельца проверяют сами методы),
+// операторы видят все карты и блокируют их, замораживает карту владелец. Сервис переводов ходит по service-токену,
+// сервисные аккаунты - по API-ключам со scopes cards:read и cards:write.
+var cardsPolicy = authint.Policy{
+	cardpb.CardService_CreateCard_FullMethodName:         authint.Allow(models.RoleCustomer).OrScope(authint.ScopeCardsWrite),
+	cardpb.CardService_GetCard_FullMethodName:            authint.Allow(models.RoleCustomer).OrService().OrScope(authint.ScopeCardsRead),
+	cardpb.CardService_ListCards_FullMethodName:          authint.Allow(models.RoleCustomer).OrService().OrScope(authint.ScopeCardsRead),
+	cardpb.CardService_DeleteCard_FullMethodName:         authint.Allow(models.RoleCustomer).OrScope(authint.ScopeCardsWrite),
+	cardpb.CardService_CheckRecipientCard_FullMethodName: authint.Allow(models.RoleCustomer).OrService().OrScope(authint.ScopeCardsRead),
+	cardpb.CardService_BlockCard_FullMethodName:          authint.Allow(models.RoleOperator).OrService().OrScope(authint.ScopeCardsWrite),
+	cardpb.CardService_FreezeCard_FullMethodName:         authint.Allow(models.RoleCustomer).OrScope(authint.ScopeCardsWrite),
+	cardpb.CardService_UnfreezeCard_FullMethodName:       authint.Allow(models.RoleCustomer).OrScope(authint.ScopeCardsWrite),
+	cardpb.CardService_ReissueCard_FullMethodName:        authint.Allow(models.RoleCustomer).OrScope(authint.ScopeCardsWrite),
+	cardpb.CardService_GetCardLimits_FullMethodName:      authint.Allow(models.RoleCustomer).OrService().OrScope(authint.ScopeCardsRead),
+	cardpb.CardService_SetCardLimits_FullMethodName:      authint.Allow(models.RoleCustomer).OrScope(authint.ScopeCardsWrite),
+}.Merge(authint.ReflectionPolicy)
+
 type server struct {
 	cardpb.UnimplementedCardServiceServer
 	mu    sync.Mutex
 	cards map[string]models.Card
+	users authpb.AuthServiceClient // владельцы карт - пользователи AuthService
+
+	recipientChecks *rateLimiter          // лимит CheckRecipientCard на вызывающего
+	numbers         *cardnumber.Generator // номера новых карт по BIN их типа
+	expiry          expiryConfig
+	notifier        notifier.Notifier // предупреждения об истечении срока карт
 }
 
-func generateCardNumber() string {
-	return fmt.Sprintf("%016d", rand.Intn(10000000000000000))
+// maxCardNumberAttempts - сколько раз выпустить номер заново, если он уже занят
+const maxCardNumberAttempts = 5
+
+// dbExecer - *sql.DB или *sql.Tx
+type dbExecer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
 }
 
-func generateExpiryDate() string {
-	now := time.Now()
-	return fmt.Sprintf("%02d-%02d-%02d", now.Year()+5, now.Month(), now.Day())
+// validateCardNumber - ошибка InvalidArgument для номера неверной длины или не из цифр.
+// Контрольная цифра не проверяется: у карт, выпущенных до BIN-диапазонов, её нет.
+func validateCardNumber(number string) error {
+	if cardnumber.ValidateFormat(number) != nil {
+		return status.Error(codes.InvalidArgument, "invalid card number")
+	}
+	return nil
+}
+
+// newCardExpiry - срок новой карты: тот же месяц через ValidityYears лет
+func (s *server) newCardExpiry(now time.Time) (month, year int) {
+	now = now.UTC()
+	return int(now.Month()), now.Year() + s.expiry.ValidityYears
+}
+
+// insertCard выпускает номер и сохраняет карту. Занятый номер выпускается заново;
+// ON CONFLICT вместо ошибки уникальности не прерывает транзакцию, если db - *sql.Tx.
+func (s *server) insertCard(ctx context.Context, db dbExecer, card *models.Card) error {
+	for attempt := 1; attempt <= maxCardNumberAttempts; attempt++ {
+		number, err := s.numbers.Generate(card.CardType)
+		if err != nil {
+			log.Printf("Ошибка при выпуске номера карты: %v", err)
+			return status.Error(codes.Internal, "could not generate card number")
+		}
+
+		res, err := db.ExecContext(ctx, `INSERT INTO cards (user_id, card_type, card_number, expiry_month, expiry_year, status, username, currency, replaces)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, '')) ON CONFLICT DO NOTHING`,
+			card.UserID, card.CardType, number, card.ExpiryMonth, card.ExpiryYear, card.Status, card.Username, card.Currency, card.Replaces)
+		if err != nil {
+			return err
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return err
+		} else if n == 1 {
+			card.CardNumber = number
+			return nil
+		}
+	}
+	log.Printf("Не удалось выпустить свободный номер карты типа %s за %d попыток", card.CardType, maxCardNumberAttempts)
+	return status.Error(codes.ResourceExhausted, "could not allocate a card number, try again later")
 }
 
+// CreateCard выпускает карту вызывающему. Username в запросе задаёт другого владельца,
+// это доступно только операторам и сервисным аккаунтам со scope cards:write.
 func (s *server) CreateCard(ctx context.Context, req *cardpb.CreateCardRequest) (*cardpb.CreateCardResponse, error) {
+	caller, err := authint.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !s.numbers.Supports(req.CardType) {
+		return nil, status.Error(codes.InvalidArgument, "unsupported card type")
+	}
+	if req.Currency == "" {
+		req.Currency = models.DefaultCurrency
+	}
+	if !models.ValidCurrency(req.Currency) {
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported currency, expected one of %v", models.Currencies)
+	}
+	owner, err := s.cardOwner(ctx, caller, req.Username)
+	if err != nil {
+		return nil, err
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	cardNumber := generateCardNumber()
-	cardExpiryDate := generateExpiryDate()
-
 	newCard := models.Card{
-		CardType:       req.CardType,
-		CardNumber:     cardNumber,
-		CardExpiryDate: cardExpiryDate,
-		Availability:   true,
-		Username:       req.Username,
+		UserID:   owner.UserId,
+		CardType: req.CardType,
+		Status:   models.CardStatusActive,
+		Username: owner.Username,
+		Currency: req.Currency,
 	}
+	newCard.ExpiryMonth, newCard.ExpiryYear = s.newCardExpiry(time.Now())
 
 	// Сохраняем новую карту в sql базе данных
-	_, err := usfl.DB.Exec("INSERT INTO cards (card_type, card_number, card_expiry_date, availability, username) VALUES ($1, $2, $3, $4, $5)",
-		newCard.CardType, newCard.CardNumber, newCard.CardExpiryDate, newCard.Availability, newCard.Username)
-
-	if err != nil {
+	if err := s.insertCard(ctx, usfl.DB, &newCard); err != nil {
 		return nil, err
 	}
 
 	return &cardpb.CreateCardResponse{
-		CardNumber: cardNumber,
+		CardNumber: newCard.CardNumber,
 		Message:    "Card created successfully",
+		Currency:   newCard.Currency,
 	}, nil
 }
 
+// cardOwner находит владельца новой карты в AuthService: id из токена может
+// принадлежать уже заблокированному пользователю, а имя из запроса - любому
+func (s *server) cardOwner(ctx context.Context, caller *authint.Identity, username string) (*authpb.UserInfo, error) {
+	req := &authpb.GetUserRequest{Username: username}
+	self := !caller.Service && !caller.IsServiceAccount() && (username == "" || username == caller.Username)
+	if self {
+		req = &authpb.GetUserRequest{UserId: caller.UserID}
+	} else {
+		if !caller.HasRole(models.RoleOperator) && !caller.IsServiceAccount() {
+			return nil, status.Error(codes.PermissionDenied, "cannot create cards for another user")
+		}
+		if username == "" {
+			return nil, status.Error(codes.InvalidArgument, "username of the card owner is required")
+		}
+	}
+
+	owner, err := s.users.GetUser(ctx, req)
+	if status.Code(err) == codes.NotFound {
+		return nil, status.Error(codes.NotFound, "card owner not found")
+	}
+	if err != nil {
+		log.Printf("Ошибка при получении владельца карты из AuthService: %v", err)
+		return nil, status.Error(codes.Unavailable, "could not resolve card owner")
+	}
+	if owner.Status != models.UserStatusActive {
+		return nil, status.Error(codes.FailedPrecondition, "card owner is not active")
+	}
+	return owner, nil
+}
+
 func (s *server) getCardInfo(cardNumber string) (models.Card, error) {
 	var cardInfo models.Card
 
-	row := usfl.DB.QueryRow("SELECT user_id, card_type, card_number, card_expiry_date, availability, username, balance FROM cards WHERE card_number = $1", cardNumber)
-	err := row.Scan(&cardInfo.UserID, &cardInfo.CardType, &cardInfo.CardNumber, &cardInfo.CardExpiryDate, &cardInfo.Availability, &cardInfo.Username, &cardInfo.Balance)
+	row := usfl.DB.QueryRow("SELECT "+cardColumns+", balance FROM cards WHERE card_number = $1", cardNumber)
+	err := row.Scan(append(cardFields(&cardInfo), &cardInfo.Balance)...)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -79,6 +196,14 @@ func (s *server) getCardInfo(cardNumber string) (models.Card, error) {
 }
 
 func (s *server) GetCard(ctx context.Context, req *cardpb.GetCardRequest) (*cardpb.GetCardResponse, error) {
+	caller, err := authint.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateCardNumber(req.CardNumber); err != nil {
+		return nil, err
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -87,22 +212,62 @@ func (s *server) GetCard(ctx context.Context, req *cardpb.GetCardRequest) (*card
 		return nil, fmt.Errorf("ошибка при получении карты %v", err)
 	}
 
-	return &cardpb.GetCardResponse{
+	// Чужая карта выглядит так же, как несуществующая, чтобы нельзя было перебирать номера
+	if !caller.CanViewUser(card.UserID) {
+		return &cardpb.GetCardResponse{}, nil
+	}
+
+	return cardToProto(card), nil
+}
+
+func cardToProto(card models.Card) *cardpb.GetCardResponse {
+	resp := &cardpb.GetCardResponse{
 		UserId:         card.UserID,
 		CardType:       card.CardType,
 		CardNumber:     card.CardNumber,
-		CardExpiryDate: card.CardExpiryDate,
-		Availability:   card.Availability,
+		CardExpiryDate: fmt.Sprintf("%02d/%02d", card.ExpiryMonth, card.ExpiryYear%100),
+		ExpiryMonth:    int32(card.ExpiryMonth),
+		ExpiryYear:     int32(card.ExpiryYear),
+		ReplacedBy:     card.ReplacedBy,
+		Replaces:       card.Replaces,
 		Username:       card.Username,
 		Balance:        card.Balance,
-	}, nil
+		Currency:       card.Currency,
+		CloseReason:    card.CloseReason,
+		Status:         card.Status,
+		StatusReason:   card.StatusReason,
+	}
+	if card.ClosedAt != nil {
+		resp.ClosedAt = card.ClosedAt.UTC().Format(time.RFC3339)
+	}
+	if card.StatusChangedAt != nil {
+		resp.StatusChangedAt = card.StatusChangedAt.UTC().Format(time.RFC3339)
+	}
+	return resp
+}
+
+// cardColumns - поля карты без баланса, в порядке cardFields
+const cardColumns = "user_id, card_type, card_number, expiry_month, expiry_year, status, username, currency, COALESCE(status_reason, ''), status_changed_at, closed_at, COALESCE(close_reason, ''), COALESCE(replaced_by, ''), COALESCE(replaces, '')"
+
+func cardFields(card *models.Card) []any {
+	return []any{&card.UserID, &card.CardType, &card.CardNumber, &card.ExpiryMonth, &card.ExpiryYear, &card.Status, &card.Username, &card.Currency,
+		&card.StatusReason, &card.StatusChangedAt, &card.ClosedAt, &card.CloseReason, &card.ReplacedBy, &card.Replaces}
 }
 
 func (s *server) ListCards(ctx context.Context, req *cardpb.ListCardsRequest) (*cardpb.ListCardsResponse, error) {
+	caller, err := authint.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !caller.CanViewUser(req.UserId) {
+		return nil, status.Error(codes.PermissionDenied, "cannot list cards of another user")
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	rows, err := usfl.DB.Query("SELECT user_id, card_type, card_number, card_expiry_date, availability, username FROM cards WHERE user_id = $1", req.UserId)
+	// Закрытые карты тоже возвращаются, чтобы по ним можно было запросить историю
+	rows, err := usfl.DB.Query("SELECT "+cardColumns+", balance FROM cards WHERE user_id = $1", req.UserId)
 	if err != nil {
 		return nil, err
 	}
@@ -111,17 +276,10 @@ func (s *server) ListCards(ctx context.Context, req *cardpb.ListCardsRequest) (*
 	var cardList []*cardpb.GetCardResponse
 	for rows.Next() {
 		var card models.Card
-		if err := rows.Scan(&card.UserID, &card.CardType, &card.CardNumber, &card.CardExpiryDate, &card.Availability, &card.Username); err != nil {
+		if err := rows.Scan(append(cardFields(&card), &card.Balance)...); err != nil {
 			return nil, err
 		}
-		cardList = append(cardList, &cardpb.GetCardResponse{
-			UserId:         card.UserID,
-			CardType:       card.CardType,
-			CardNumber:     card.CardNumber,
-			CardExpiryDate: card.CardExpiryDate,
-			Availability:   card.Availability,
-			Username:       card.Username,
-		})
+		cardList = append(cardList, cardToProto(card))
 	}
 
 	if err := rows.Err(); err != nil {
@@ -143,10 +301,16 @@ func startGRPCserver() {
 	}
 
 	// Call the DbConnector method
+	schemaReady := false
 	if err := connPostgres.DbConnector(); err != nil {
 		fmt.Println("Error connecting to the database:", err)
 	} else {
 		fmt.Println("Successfully connected to the database!")
+		if err := ensureSchema(); err != nil {
+			log.Printf("Не удалось обновить схему карт, закрытие и перевыпуск карт недоступны: %v", err)
+		} else {
+			schemaReady = true
+		}
 	}
 
 	lis, err := net.Listen("tcp", ":50051")
@@ -154,9 +318,48 @@ func startGRPCserver() {
 		log.Fatalf("failed to listen: %v", err)
 	}
 
-	s := grpc.NewServer()
-	cardpb.RegisterCardServiceServer(s, &server{cards: make(map[string]models.Card)})
+	// Токены проверяются по публичным ключам AuthService, владельцы карт ищутся
+	// в нём же от имени сервиса
+	serviceToken := os.Getenv(authint.ServiceTokenEnv)
+	if serviceToken == "" {
+		log.Printf("Не задан %s, AuthService не даст искать владельцев карт", authint.ServiceTokenEnv)
+	}
+	authConn, err := grpc.Dial("localhost:50054", grpc.WithInsecure(),
+		grpc.WithPerRPCCredentials(authint.BearerToken(serviceToken)))
+	if err != nil {
+		log.Fatalf("не удалось подключиться к AuthService: %v", err)
+	}
+	defer authConn.Close()
+	authClient := authpb.NewAuthServiceClient(authConn)
+	validator := authint.ValidatorFromEnv(authClient)
+	authOpts := []authint.Option{authint.WithServiceToken(serviceToken)}
+
+	numbers, err := cardnumber.FromEnv()
+	if err != nil {
+		log.Fatalf("неверные диапазоны BIN в %s: %v", cardnumber.RangesEnv, err)
+	}
+
+	s := grpc.NewServer(
+		grpc.UnaryInterceptor(authint.UnaryServerInterceptor(validator, cardsPolicy, authOpts...)),
+		grpc.StreamInterceptor(authint.StreamServerInterceptor(validator, cardsPolicy, authOpts...)),
+	)
+	srv := &server{
+		cards:           make(map[string]models.Card),
+		users:           authClient,
+		recipientChecks: newRateLimiter(loadRecipientCheckConfig()),
+		numbers:         numbers,
+		expiry:          loadExpiryConfig(),
+		notifier:        notifier.FromEnv(),
+	}
+	cardpb.RegisterCardServiceServer(s, srv)
 	reflection.Register(s)
+	if err := cardsPolicy.Check(s); err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	if schemaReady {
+		go srv.runExpiry()
+	}
 
 	log.Printf("server listening at %v", lis.Addr())
 	if err := s.Serve(lis); err != nil {
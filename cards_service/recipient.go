@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	authint "fin-trans/auth_interceptor_package"
+	models "fin-trans/models_package"
+	cardpb "fin-trans/proto/proto_generated/cards_service"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// CheckRecipientCard сообщает, можно ли перевести деньги на карту, и показывает
+// имя держателя в виде "Ivan P.", чтобы отправитель убедился, что не ошибся номером.
+// Несуществующая карта неотличима от недоступной.
+func (s *server) CheckRecipientCard(ctx context.Context, req *cardpb.CheckRecipientCardRequest) (*cardpb.CheckRecipientCardResponse, error) {
+	caller, err := authint.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateCardNumber(req.RecipientCardNumber); err != nil {
+		return nil, err
+	}
+	if err := s.recipientChecks.allow(recipientCheckKey(caller, req.OnBehalfOfUserId)); err != nil {
+		return nil, err
+	}
+
+	card, err := s.getCardInfo(req.RecipientCardNumber)
+	if err != nil {
+		log.Printf("Ошибка при получении карты получателя: %v", err)
+		return nil, status.Error(codes.Internal, "could not load card")
+	}
+	if card.CardNumber == "" || card.Status != models.CardStatusActive {
+		return &cardpb.CheckRecipientCardResponse{Availability: false}, nil
+	}
+
+	return &cardpb.CheckRecipientCardResponse{
+		Availability:      true,
+		RecipientUsername: maskedName(card.Username),
+		Currency:          card.Currency,
+	}, nil
+}
+
+// recipientCheckKey - чей лимит расходует проверка. Сервис переводов вызывает метод
+// от своего имени и передаёт пользователя в on_behalf_of_user_id; другим вызывающим
+// это поле недоступно. Собственные проверки сервиса не ограничиваются.
+func recipientCheckKey(caller *authint.Identity, onBehalfOf int32) string {
+	switch {
+	case caller.Service && onBehalfOf != 0:
+		return fmt.Sprintf("user:%d", onBehalfOf)
+	case caller.Service:
+		return ""
+	case caller.IsServiceAccount():
+		return fmt.Sprintf("service_account:%d", caller.ServiceAccountID)
+	default:
+		return fmt.Sprintf("user:%d", caller.UserID)
+	}
+}
+
+// maskedName превращает имя держателя вида "ivan.petrov" в "Ivan P.".
+// Из имени из одной части остаётся только первая буква.
+func maskedName(username string) string {
+	parts := strings.FieldsFunc(username, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '.' || r == '_' || r == '-'
+	})
+	if len(parts) == 0 {
+		return ""
+	}
+
+	first := []rune(strings.ToLower(parts[0]))
+	first[0] = unicode.ToUpper(first[0])
+	if len(parts) == 1 {
+		return string(first[0]) + "."
+	}
+	last := []rune(parts[len(parts)-1])
+	return string(first) + " " + string(unicode.ToUpper(last[0])) + "."
+}
+
+// rateWindow - проверки одного вызывающего в текущем окне
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+// rateLimiter - фиксированное окно на ключ. Счётчики хранятся в памяти реплики.
+type rateLimiter struct {
+	cfg       recipientCheckConfig
+	mu        sync.Mutex
+	windows   map[string]*rateWindow
+	lastPrune time.Time
+}
+
+func newRateLimiter(cfg recipientCheckConfig) *rateLimiter {
+	return &rateLimiter{cfg: cfg, windows: make(map[string]*rateWindow)}
+}
+
+// allow учитывает вызов и возвращает ResourceExhausted, если лимит окна исчерпан.
+// Пустой ключ не ограничивается.
+func (l *rateLimiter) allow(key string) error {
+	if key == "" {
+		return nil
+	}
+	now := time.Now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastPrune) > l.cfg.Window {
+		for k, w := range l.windows {
+			if now.Sub(w.start) >= l.cfg.Window {
+				delete(l.windows, k)
+			}
+		}
+		l.lastPrune = now
+	}
+
+	w, ok := l.windows[key]
+	if !ok || now.Sub(w.start) >= l.cfg.Window {
+		w = &rateWindow{start: now}
+		l.windows[key] = w
+	}
+	if w.count >= l.cfg.Limit {
+		return rateLimitedError(w.start.Add(l.cfg.Window).Sub(now))
+	}
+	w.count++
+	return nil
+}
+
+// rateLimitedError - ResourceExhausted с RetryInfo, чтобы клиент знал, когда повторить
+func rateLimitedError(wait time.Duration) error {
+	wait = wait.Truncate(time.Second) + time.Second
+	st := status.New(codes.ResourceExhausted, fmt.Sprintf("too many recipient checks, retry in %v", wait))
+	if detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(wait)}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	usfl "fin-trans/database_methods_package"
+	"fin-trans/transactions_service/history"
+	"fin-trans/transactions_service/limits"
+	"fin-trans/transactions_service/pending"
+)
+
+// cardsSchema дополняет таблицу cards полями закрытия, статуса, срока, перевыпуска и валюты карты.
+// Флаг availability заменяется статусом: снятая с обслуживания карта считается
+// заблокированной банком, закрытая - закрытой. Строка card_expiry_date вида
+// "2031-10-18" заменяется месяцем и годом. Новая карта выпускается с нулевым балансом,
+// деньги на неё зачисляются пополнением.
+const cardsSchema = `
+ALTER TABLE cards ADD COLUMN IF NOT EXISTS closed_at TIMESTAMPTZ;
+ALTER TABLE cards ADD COLUMN IF NOT EXISTS close_reason TEXT;
+ALTER TABLE cards ADD COLUMN IF NOT EXISTS status TEXT;
+ALTER TABLE cards ADD COLUMN IF NOT EXISTS status_reason TEXT;
+ALTER TABLE cards ADD COLUMN IF NOT EXISTS status_changed_by TEXT;
+ALTER TABLE cards ADD COLUMN IF NOT EXISTS status_changed_at TIMESTAMPTZ;
+DO $$
+BEGIN
+	IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'cards' AND column_name = 'availability') THEN
+		UPDATE cards SET status = CASE
+			WHEN closed_at IS NOT NULL THEN 'CLOSED'
+			WHEN NOT availability THEN 'BLOCKED'
+			ELSE 'ACTIVE' END
+		WHERE status IS NULL;
+		ALTER TABLE cards DROP COLUMN availability;
+	END IF;
+END $$;
+UPDATE cards SET status = 'ACTIVE' WHERE status IS NULL;
+ALTER TABLE cards ALTER COLUMN status SET DEFAULT 'ACTIVE';
+ALTER TABLE cards ALTER COLUMN status SET NOT NULL;
+ALTER TABLE cards ADD COLUMN IF NOT EXISTS expiry_month SMALLINT;
+ALTER TABLE cards ADD COLUMN IF NOT EXISTS expiry_year SMALLINT;
+DO $$
+BEGIN
+	IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'cards' AND column_name = 'card_expiry_date') THEN
+		UPDATE cards SET
+			expiry_year = split_part(card_expiry_date, '-', 1)::SMALLINT,
+			expiry_month = split_part(card_expiry_date, '-', 2)::SMALLINT
+		WHERE expiry_month IS NULL AND card_expiry_date ~ '^[0-9]{4}-[0-9]{1,2}-[0-9]{1,2}$';
+		ALTER TABLE cards DROP COLUMN card_expiry_date;
+	END IF;
+END $$;
+UPDATE cards SET expiry_month = EXTRACT(MONTH FROM now()), expiry_year = EXTRACT(YEAR FROM now()) + 5 WHERE expiry_month IS NULL OR expiry_year IS NULL;
+ALTER TABLE cards ALTER COLUMN expiry_month SET NOT NULL;
+ALTER TABLE cards ALTER COLUMN expiry_year SET NOT NULL;
+ALTER TABLE cards ADD COLUMN IF NOT EXISTS expiry_notified_at TIMESTAMPTZ;
+ALTER TABLE cards ADD COLUMN IF NOT EXISTS replaced_by TEXT;
+ALTER TABLE cards ADD COLUMN IF NOT EXISTS replaces TEXT;
+ALTER TABLE cards ADD COLUMN IF NOT EXISTS currency TEXT NOT NULL DEFAULT 'RUB';
+ALTER TABLE cards ALTER COLUMN balance SET DEFAULT 0;
+CREATE TABLE IF NOT EXISTS card_status_history (
+	id          BIGSERIAL PRIMARY KEY,
+	card_number TEXT NOT NULL,
+	from_status TEXT NOT NULL,
+	to_status   TEXT NOT NULL,
+	reason      TEXT NOT NULL,
+	actor       TEXT NOT NULL,
+	changed_at  TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS card_status_history_card_idx ON card_status_history (card_number, changed_at)`
+
+func ensureSchema() error {
+	if _, err := usfl.DB.Exec(cardsSchema); err != nil {
+		return err
+	}
+	// Незавершённые переводы ведёт сервис переводов, DeleteCard их только читает
+	if err := pending.EnsureSchema(); err != nil {
+		return err
+	}
+	// Остатки закрытых и перевыпущенных карт записываются в историю переводов
+	if err := history.EnsureSchema(); err != nil {
+		return err
+	}
+	return limits.EnsureSchema()
+}
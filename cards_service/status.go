@@ -0,0 +1,216 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	authint "fin-trans/auth_interceptor_package"
+	usfl "fin-trans/database_methods_package"
+	models "fin-trans/models_package"
+	cardpb "fin-trans/proto/proto_generated/cards_service"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// cardTransitions - разрешённые переходы между статусами карты.
+// Заблокированная банком карта в ACTIVE не возвращается, CLOSED - конечный статус.
+var cardTransitions = map[string][]string{
+	models.CardStatusActive:  {models.CardStatusFrozen, models.CardStatusBlocked, models.CardStatusExpired, models.CardStatusClosed},
+	models.CardStatusFrozen:  {models.CardStatusActive, models.CardStatusBlocked, models.CardStatusExpired, models.CardStatusClosed},
+	models.CardStatusBlocked: {models.CardStatusExpired, models.CardStatusClosed},
+	models.CardStatusExpired: {models.CardStatusClosed},
+}
+
+// Причины, с которыми карту можно заморозить и заблокировать
+var (
+	freezeReasons = []string{models.CardReasonCustomerRequest, models.CardReasonLost}
+	blockReasons  = []string{models.CardReasonLost, models.CardReasonStolen, models.CardReasonFraudSuspected, models.CardReasonCompliance}
+)
+
+var (
+	errCardNotFound = errors.New("карта не найдена")
+	errTransition   = errors.New("недопустимая смена статуса карты")
+)
+
+// canTransition - разрешён ли переход from -> to
+func canTransition(from, to string) bool {
+	for _, next := range cardTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// statusActor - кто сменил статус, для истории статусов
+func statusActor(caller *authint.Identity) string {
+	switch {
+	case caller == nil:
+		return "system"
+	case caller.Service:
+		return "service"
+	case caller.IsServiceAccount():
+		return fmt.Sprintf("service_account:%d", caller.ServiceAccountID)
+	default:
+		return fmt.Sprintf("user:%d", caller.UserID)
+	}
+}
+
+// validReason - reason входит в allowed
+func validReason(reason string, allowed []string) bool {
+	for _, r := range allowed {
+		if r == reason {
+			return true
+		}
+	}
+	return false
+}
+
+// lockCardStatus блокирует строку карты до конца транзакции и возвращает её владельца и статус
+func lockCardStatus(ctx context.Context, tx *sql.Tx, number string) (int32, string, error) {
+	var userID int32
+	var current string
+	err := tx.QueryRowContext(ctx, "SELECT user_id, status FROM cards WHERE card_number = $1 FOR UPDATE", number).Scan(&userID, &current)
+	if err == sql.ErrNoRows {
+		return 0, "", errCardNotFound
+	}
+	return userID, current, err
+}
+
+// recordCardStatus сохраняет новый статус карты и строку истории в транзакции tx
+func recordCardStatus(ctx context.Context, tx *sql.Tx, number, from, to, reason, actor string, at time.Time) error {
+	if _, err := tx.ExecContext(ctx, "UPDATE cards SET status = $2, status_reason = $3, status_changed_by = $4, status_changed_at = $5 WHERE card_number = $1",
+		number, to, reason, actor, at); err != nil {
+		return err
+	}
+	_, err := tx.ExecContext(ctx, "INSERT INTO card_status_history (card_number, from_status, to_status, reason, actor, changed_at) VALUES ($1, $2, $3, $4, $5, $6)",
+		number, from, to, reason, actor, at)
+	return err
+}
+
+// setCardStatus переводит карту в статус to, если переход разрешён и allowed
+// пропускает вызывающего к карте владельца userID. Возвращает прежний статус.
+func setCardStatus(ctx context.Context, number, to, reason, actor string, allowed func(userID int32) bool) (string, error) {
+	tx, err := usfl.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	userID, from, err := lockCardStatus(ctx, tx, number)
+	if err != nil {
+		return "", err
+	}
+	// Чужая карта выглядит так же, как несуществующая
+	if !allowed(userID) {
+		return "", errCardNotFound
+	}
+	if !canTransition(from, to) {
+		return from, errTransition
+	}
+	if err := recordCardStatus(ctx, tx, number, from, to, reason, actor, time.Now().UTC()); err != nil {
+		return from, err
+	}
+	return from, tx.Commit()
+}
+
+// statusChangeResult - ответ метода смены статуса по ошибке setCardStatus
+func statusChangeResult(number, from, to string, err error) (bool, string, string, error) {
+	switch {
+	case err == nil:
+		return true, "Card status changed to " + to, to, nil
+	case errors.Is(err, errCardNotFound):
+		return false, "Card not found", "", nil
+	case errors.Is(err, errTransition):
+		return false, fmt.Sprintf("Card in status %s cannot become %s", from, to), from, nil
+	default:
+		log.Printf("Ошибка при смене статуса карты %s на %s: %v", number, to, err)
+		return false, "", "", status.Error(codes.Internal, "could not change card status")
+	}
+}
+
+// FreezeCard временно запрещает переводы с карты и на карту по просьбе владельца.
+// Операторы чужие карты не замораживают, а блокируют через BlockCard.
+func (s *server) FreezeCard(ctx context.Context, req *cardpb.FreezeCardRequest) (*cardpb.FreezeCardResponse, error) {
+	caller, err := authint.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateCardNumber(req.CardNumber); err != nil {
+		return nil, err
+	}
+	if req.Reason == "" {
+		req.Reason = models.CardReasonCustomerRequest
+	}
+	if !validReason(req.Reason, freezeReasons) {
+		return nil, status.Errorf(codes.InvalidArgument, "reason must be one of %v", freezeReasons)
+	}
+
+	from, err := setCardStatus(ctx, req.CardNumber, models.CardStatusFrozen, req.Reason, statusActor(caller), caller.CanAccessUser)
+	ok, msg, st, err := statusChangeResult(req.CardNumber, from, models.CardStatusFrozen, err)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		log.Printf("Карта %s заморожена пользователем %s: %s", req.CardNumber, caller.Username, req.Reason)
+	}
+	return &cardpb.FreezeCardResponse{Success: ok, Message: msg, Status: st}, nil
+}
+
+// UnfreezeCard возвращает замороженную карту в ACTIVE. Заблокированную банком
+// карту так разблокировать нельзя.
+func (s *server) UnfreezeCard(ctx context.Context, req *cardpb.UnfreezeCardRequest) (*cardpb.UnfreezeCardResponse, error) {
+	caller, err := authint.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateCardNumber(req.CardNumber); err != nil {
+		return nil, err
+	}
+
+	from, err := setCardStatus(ctx, req.CardNumber, models.CardStatusActive, models.CardReasonCustomerRequest, statusActor(caller), caller.CanAccessUser)
+	if errors.Is(err, errTransition) && from == models.CardStatusActive {
+		// ACTIVE -> ACTIVE не переход, но и ошибкой для клиента не является
+		return &cardpb.UnfreezeCardResponse{Success: true, Message: "Card is already active", Status: from}, nil
+	}
+	ok, msg, st, err := statusChangeResult(req.CardNumber, from, models.CardStatusActive, err)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		log.Printf("Карта %s разморожена пользователем %s", req.CardNumber, caller.Username)
+	}
+	return &cardpb.UnfreezeCardResponse{Success: ok, Message: msg, Status: st}, nil
+}
+
+// BlockCard блокирует карту по решению банка: переводы запрещены, владелец
+// снять блокировку не может
+func (s *server) BlockCard(ctx context.Context, req *cardpb.BlockCardRequest) (*cardpb.BlockCardResponse, error) {
+	caller, err := authint.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateCardNumber(req.CardNumber); err != nil {
+		return nil, err
+	}
+	if !validReason(req.Reason, blockReasons) {
+		return nil, status.Errorf(codes.InvalidArgument, "reason must be one of %v", blockReasons)
+	}
+
+	// Права на метод проверила политика: блокировать можно любые карты
+	anyone := func(int32) bool { return true }
+	from, err := setCardStatus(ctx, req.CardNumber, models.CardStatusBlocked, req.Reason, statusActor(caller), anyone)
+	ok, msg, st, err := statusChangeResult(req.CardNumber, from, models.CardStatusBlocked, err)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		log.Printf("Карта %s заблокирована пользователем %s: %s", req.CardNumber, caller.Username, req.Reason)
+	}
+	return &cardpb.BlockCardResponse{Success: ok, Message: msg, Status: st}, nil
+}
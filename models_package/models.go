@@ -1,21 +1,311 @@
 package models
 
 import (
+	"time"
+
 	_ "github.com/lib/pq" // Импортируем драйвер PostgreSQL
 )
 
 type Card struct {
-	UserID         int32   `gorm:"not null"`
-	CardType       string  `gorm:"not null"`
-	CardNumber     string  `gorm:"not null;unique"`
-	CardExpiryDate string  `gorm:"not null"`
-	Availability   bool    `gorm:"default:true"`
-	Username       string  `gorm:"not null"`
-	Balance        float64 `gorm:"default:100"`
+	UserID      int32   `gorm:"not null"`
+	CardType    string  `gorm:"not null"`
+	CardNumber  string  `gorm:"not null;unique"`
+	ExpiryMonth int     `gorm:"not null"` // 1-12, карта действует до конца этого месяца
+	ExpiryYear  int     `gorm:"not null"`
+	Status      string  `gorm:"not null;default:ACTIVE"`
+	Username    string  `gorm:"not null"`
+	Balance     float64 `gorm:"default:0"`            // меняется только переводами, пополнениями и списаниями
+	Currency    string  `gorm:"not null;default:RUB"` // код ISO 4217, в нём ведётся Balance
+	// Причина и автор последней смены статуса
+	StatusReason    string
+	StatusChangedBy string
+	StatusChangedAt *time.Time
+	// Закрытая карта не удаляется, чтобы по ней оставалась история переводов
+	ClosedAt    *time.Time
+	CloseReason string
+	// Перевыпуск связывает старую и новую карты
+	ReplacedBy string
+	Replaces   string
+}
+
+// CardExpiresAt - момент, с которого карта со сроком month/year просрочена:
+// карта действует до конца указанного месяца по UTC
+func CardExpiresAt(month, year int) time.Time {
+	return time.Date(year, time.Month(month)+1, 1, 0, 0, 0, 0, time.UTC)
 }
 
+// CardExpired - истёк ли к моменту now срок карты month/year
+func CardExpired(month, year int, now time.Time) bool {
+	return !now.Before(CardExpiresAt(month, year))
+}
+
+// Валюты карт. Карты, выпущенные до появления валют, рублёвые.
+const (
+	CurrencyRUB     = "RUB"
+	CurrencyUSD     = "USD"
+	CurrencyEUR     = "EUR"
+	DefaultCurrency = CurrencyRUB
+)
+
+// Currencies - валюты, в которых выпускаются карты
+var Currencies = []string{CurrencyRUB, CurrencyUSD, CurrencyEUR}
+
+// ValidCurrency - выпускаются ли карты в валюте code
+func ValidCurrency(code string) bool {
+	for _, c := range Currencies {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// Виды операций с балансом. Пополнение и списание приходят из внешних систем
+// с их идентификатором операции и проводятся не больше одного раза.
+const (
+	OperationTransfer   = "TRANSFER"
+	OperationDeposit    = "DEPOSIT"    // внешняя система зачисляет деньги на карту
+	OperationWithdrawal = "WITHDRAWAL" // внешняя система списывает деньги с карты
+)
+
+// Статусы карты. Переводы возможны только с ACTIVE на ACTIVE, CLOSED - конечный статус.
+const (
+	CardStatusActive  = "ACTIVE"
+	CardStatusFrozen  = "FROZEN"  // заморожена владельцем, он же может разморозить
+	CardStatusBlocked = "BLOCKED" // заблокирована банком
+	CardStatusExpired = "EXPIRED"
+	CardStatusClosed  = "CLOSED"
+)
+
+// Причины смены статуса карты
+const (
+	CardReasonCustomerRequest = "CUSTOMER_REQUEST"
+	CardReasonLost            = "LOST"
+	CardReasonStolen          = "STOLEN"
+	CardReasonFraudSuspected  = "FRAUD_SUSPECTED"
+	CardReasonCompliance      = "COMPLIANCE"
+	CardReasonExpired         = "EXPIRED"
+	CardReasonReissued        = "REISSUED"
+)
+
+// FintransSuccessfulTransactionsPostgres - проведённая операция. У пополнения нет
+// карты отправителя, у списания - карты получателя.
 type FintransSuccessfulTransactionsPostgres struct {
+	Kind                   string // OperationTransfer, если пусто
+	ExternalRef            string // идентификатор пополнения или списания во внешней системе
+	CardNumber             string
+	Amount                 float64
+	RecipientCardNumber    string
+	Currency               string // валюта Amount, совпадает с валютой карты отправителя
+	Convert                bool   // отправитель согласен на перевод на карту в другой валюте
+	QuoteID                string // котировка, по курсу которой конвертируется перевод
+	Rate                   float64
+	TargetAmount           float64 // зачислено на карту получателя в TargetCurrency
+	TargetCurrency         string
+	SenderUserID           int32 // пользователь, создавший перевод; карта отправителя должна принадлежать ему
+	SenderServiceAccountID int32 // или сервисный аккаунт, которому scope разрешает переводы с любых карт
+}
+
+// TransactionMessage - перевод, ожидающий обработки в очереди RabbitMQ
+type TransactionMessage struct {
+	ID                  string  `json:"id,omitempty"` // строка в pending_transactions, пока перевод не проведён
+	Kind                string  `json:"kind,omitempty"`
+	ExternalRef         string  `json:"external_ref,omitempty"`
+	CardNumber          string  `json:"card_number"`
+	Amount              float64 `json:"amount"`
+	RecipientCardNumber string  `json:"recipient_card_number"`
+	Currency            string  `json:"currency,omitempty"`
+	Convert             bool    `json:"convert,omitempty"`
+	QuoteID             string  `json:"quote_id,omitempty"`
+	UserID              int32   `json:"user_id"`
+	// ServiceAccountID - перевод создан сервисным аккаунтом со scope transactions:create,
+	// владелец карты отправителя в этом случае не сверяется
+	ServiceAccountID int32 `json:"service_account_id,omitempty"`
+}
+
+// Статусы перевода до проведения по балансам. PENDING_CONFIRMATION, CONFIRMED и QUEUED
+// считаются незавершёнными: пока они есть, карту нельзя закрыть.
+const (
+	TransactionPendingConfirmation = "PENDING_CONFIRMATION"
+	TransactionConfirmed           = "CONFIRMED"
+	TransactionQueued              = "QUEUED" // не требовал подтверждения и сразу ушёл в очередь
+	TransactionProcessed           = "PROCESSED"
+	TransactionExpired             = "EXPIRED"
+	TransactionFailed              = "FAILED"   // подтверждён, но не попал в очередь
+	TransactionRejected            = "REJECTED" // пополнение или списание отклонено при проведении
+)
+
+// PendingTransaction - перевод, ещё не проведённый по балансам. Перевод выше порога
+// подтверждения уходит в очередь RabbitMQ только после ConfirmTransaction с кодом из AuthService.
+type PendingTransaction struct {
+	ID                  string `gorm:"primaryKey"`
+	Kind                string
+	ExternalRef         string
 	CardNumber          string
 	Amount              float64
 	RecipientCardNumber string
+	Currency            string
+	Convert             bool
+	QuoteID             string
+	UserID              int32
+	ServiceAccountID    int32  // сервисный аккаунт пополнения или списания, ExternalRef уникален в его пределах
+	ChallengeID         string // challenge кода подтверждения в AuthService
+	Status              string
+	CreatedAt           time.Time
+	ExpiresAt           time.Time
+}
+
+// FxQuote - курс конвертации, зафиксированный для вызывающего до ExpiresAt.
+// Котировкой можно оплатить один перевод, UsedAt ставится при создании перевода.
+type FxQuote struct {
+	ID           string `gorm:"primaryKey"`
+	Owner        string // "user:N" или "service_account:N"
+	FromCurrency string
+	ToCurrency   string
+	Rate         float64 // сколько единиц ToCurrency стоит одна единица FromCurrency
+	CreatedAt    time.Time
+	ExpiresAt    time.Time
+	UsedAt       *time.Time
+}
+
+// Статусы учётной записи пользователя
+const (
+	UserStatusActive  = "active"
+	UserStatusBlocked = "blocked"
+)
+
+// Роли пользователей, по возрастанию прав: каждая следующая включает предыдущие
+const (
+	RoleCustomer = "customer" // клиент, работает только со своими картами
+	RoleOperator = "operator" // сотрудник поддержки: блокирует карты, видит любые переводы
+	RoleAdmin    = "admin"    // управляет учётными записями
+)
+
+// User - учётная запись пользователя сервиса аутентификации.
+// ID совпадает с user_id в таблице cards.
+type User struct {
+	ID           int32     `gorm:"primaryKey"`
+	Username     string    `gorm:"not null;unique"`
+	PasswordHash string    `gorm:"not null"`
+	CreatedAt    time.Time `gorm:"not null"`
+	Status       string    `gorm:"not null;default:active"`
+	Role         string    `gorm:"not null;default:customer"`
+}
+
+// Session - сессия пользователя: один логин на одном устройстве.
+// ID совпадает с семейством refresh-токенов и передаётся в access-токене как sid.
+type Session struct {
+	ID         string
+	UserID     int32
+	Username   string
+	Token      string // последний выданный access-токен
+	DeviceName string
+	UserAgent  string
+	IP         string
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+	ExpiresAt  time.Time // истекает вместе с refresh-токеном
+}
+
+// AuthChallenge - вход, ожидающий второй фактор, или операция другого сервиса,
+// ожидающая кода подтверждения. Хранится вместе с сессиями, чтобы код, выданный
+// одной репликой auth_service, принимала любая.
+type AuthChallenge struct {
+	ID         string
+	Kind       string // AuthChallengeLogin или AuthChallengeStepUp
+	UserID     int32
+	DeviceName string // устройство входа, для AuthChallengeLogin
+	CodeHash   string // SHA-256 кода подтверждения, для AuthChallengeStepUp
+	Attempts   int
+	ExpiresAt  time.Time
+}
+
+const (
+	AuthChallengeLogin  = "LOGIN"
+	AuthChallengeStepUp = "STEP_UP"
+)
+
+// RefreshToken - выданный refresh-токен. Сам токен не хранится, только его SHA-256.
+// Все токены, полученные ротацией из одного логина, имеют общий FamilyID.
+type RefreshToken struct {
+	TokenHash string    `gorm:"primaryKey"`
+	FamilyID  string    `gorm:"not null;index"`
+	UserID    int32     `gorm:"not null;index"`
+	Username  string    `gorm:"not null"`
+	IssuedAt  time.Time `gorm:"not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+	Rotated   bool      `gorm:"default:false"` // токен уже обменян на новый
+	Revoked   bool      `gorm:"default:false"` // семейство отозвано
+}
+
+// PasswordResetToken - запрос сброса пароля. Сам токен не хранится, только его SHA-256.
+type PasswordResetToken struct {
+	TokenHash string    `gorm:"primaryKey"`
+	UserID    int32     `gorm:"not null;index"`
+	CreatedAt time.Time `gorm:"not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+}
+
+// UserTOTP - секрет TOTP пользователя. Enabled выставляется после подтверждения кодом.
+type UserTOTP struct {
+	UserID    int32     `gorm:"primaryKey"`
+	Secret    string    `gorm:"not null"` // base32, без паддинга
+	Enabled   bool      `gorm:"default:false"`
+	LastStep  int64     `gorm:"default:0"` // последний принятый шаг, защита от повтора кода
+	CreatedAt time.Time `gorm:"not null"`
+}
+
+// RecoveryCode - одноразовый код восстановления для входа без TOTP, хранится как bcrypt-хеш
+type RecoveryCode struct {
+	ID       int64  `gorm:"primaryKey"`
+	UserID   int32  `gorm:"not null;index"`
+	CodeHash string `gorm:"not null"`
+}
+
+// Исходы событий аудита
+const (
+	AuditOutcomeSuccess   = "success"
+	AuditOutcomeFailure   = "failure"
+	AuditOutcomeLocked    = "locked"    // отклонено блокировкой от перебора
+	AuditOutcomeChallenge = "challenge" // пароль верный, ждём второй фактор
+)
+
+// AuditEvent - запись журнала аудита AuthService. Журнал только дополняется;
+// Hash покрывает содержимое записи и PrevHash, так что правка или удаление
+// любой записи ломает цепочку.
+type AuditEvent struct {
+	ID        int64     `gorm:"primaryKey"`
+	Timestamp time.Time `gorm:"not null"`
+	Event     string    `gorm:"not null"` // login, logout, register, ...
+	UserID    int32
+	Username  string
+	IP        string
+	UserAgent string
+	Outcome   string `gorm:"not null"`
+	Detail    string
+	PrevHash  string `gorm:"not null"`
+	Hash      string `gorm:"not null;unique"`
+}
+
+// ServiceAccount - учётная запись для вызовов сервис-сервис (пакетные задачи, партнёры).
+// Входит по API-ключам, а не по паролю.
+type ServiceAccount struct {
+	ID          int32  `gorm:"primaryKey"`
+	Name        string `gorm:"unique;not null"`
+	Description string
+	CreatedAt   time.Time `gorm:"not null"`
+}
+
+// APIKey - ключ сервисного аккаунта. Сам ключ показывается один раз при создании,
+// хранится только его хеш.
+type APIKey struct {
+	ID               string `gorm:"primaryKey"` // открытая часть ключа, по ней ключ ищется
+	ServiceAccountID int32  `gorm:"not null"`
+	Name             string
+	KeyHash          string    `gorm:"not null"`
+	Scopes           []string  // например transactions:create, cards:read
+	CreatedAt        time.Time `gorm:"not null"`
+	LastUsedAt       *time.Time
+	RevokedAt        *time.Time
 }
@@ -0,0 +1,78 @@
+// Package notifier доставляет пользователям служебные сообщения: ссылки сброса
+// пароля, одноразовые коды и т.п. Реальные каналы (почта, SMS) подключаются
+// реализацией интерфейса Notifier; для локального запуска есть запись в лог и в файл.
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// FileEnv - переменная окружения с путём к файлу для FileNotifier
+const FileEnv = "FINTRANS_NOTIFIER_FILE"
+
+// Message - сообщение пользователю
+type Message struct {
+	Recipient string    `json:"recipient"` // имя пользователя
+	Subject   string    `json:"subject"`
+	Body      string    `json:"body"`
+	SentAt    time.Time `json:"sent_at"`
+}
+
+// Notifier отправляет сообщение пользователю
+type Notifier interface {
+	Notify(ctx context.Context, msg Message) error
+}
+
+// FromEnv возвращает FileNotifier, если задан FINTRANS_NOTIFIER_FILE, иначе LogNotifier
+func FromEnv() Notifier {
+	if path := os.Getenv(FileEnv); path != "" {
+		return NewFileNotifier(path)
+	}
+	return LogNotifier{}
+}
+
+// LogNotifier пишет сообщения в лог сервиса
+type LogNotifier struct{}
+
+func (LogNotifier) Notify(ctx context.Context, msg Message) error {
+	log.Printf("Уведомление для %s: %s\n%s", msg.Recipient, msg.Subject, msg.Body)
+	return nil
+}
+
+// FileNotifier дописывает сообщения в файл по одному JSON на строку
+type FileNotifier struct {
+	mu   sync.Mutex
+	path string
+}
+
+func NewFileNotifier(path string) *FileNotifier {
+	return &FileNotifier{path: path}
+}
+
+func (n *FileNotifier) Notify(ctx context.Context, msg Message) error {
+	if msg.SentAt.IsZero() {
+		msg.SentAt = time.Now()
+	}
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	f, err := os.OpenFile(n.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
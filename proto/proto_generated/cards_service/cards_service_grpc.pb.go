@@ -24,6 +24,12 @@ const (
 	CardService_ListCards_FullMethodName          = "/cardservice.CardService/ListCards"
 	CardService_DeleteCard_FullMethodName         = "/cardservice.CardService/DeleteCard"
 	CardService_CheckRecipientCard_FullMethodName = "/cardservice.CardService/CheckRecipientCard"
+	CardService_BlockCard_FullMethodName          = "/cardservice.CardService/BlockCard"
+	CardService_FreezeCard_FullMethodName         = "/cardservice.CardService/FreezeCard"
+	CardService_UnfreezeCard_FullMethodName       = "/cardservice.CardService/UnfreezeCard"
+	CardService_ReissueCard_FullMethodName        = "/cardservice.CardService/ReissueCard"
+	CardService_GetCardLimits_FullMethodName      = "/cardservice.CardService/GetCardLimits"
+	CardService_SetCardLimits_FullMethodName      = "/cardservice.CardService/SetCardLimits"
 )
 
 // CardServiceClient is the client API for CardService service.
@@ -35,6 +41,12 @@ type CardServiceClient interface {
 	ListCards(ctx context.Context, in *ListCardsRequest, opts ...grpc.CallOption) (*ListCardsResponse, error)
 	DeleteCard(ctx context.Context, in *DeleteCardRequest, opts ...grpc.CallOption) (*DeleteCardResponse, error)
 	CheckRecipientCard(ctx context.Context, in *CheckRecipientCardRequest, opts ...grpc.CallOption) (*CheckRecipientCardResponse, error)
+	BlockCard(ctx context.Context, in *BlockCardRequest, opts ...grpc.CallOption) (*BlockCardResponse, error)
+	FreezeCard(ctx context.Context, in *FreezeCardRequest, opts ...grpc.CallOption) (*FreezeCardResponse, error)
+	UnfreezeCard(ctx context.Context, in *UnfreezeCardRequest, opts ...grpc.CallOption) (*UnfreezeCardResponse, error)
+	ReissueCard(ctx context.Context, in *ReissueCardRequest, opts ...grpc.CallOption) (*ReissueCardResponse, error)
+	GetCardLimits(ctx context.Context, in *GetCardLimitsRequest, opts ...grpc.CallOption) (*GetCardLimitsResponse, error)
+	SetCardLimits(ctx context.Context, in *SetCardLimitsRequest, opts ...grpc.CallOption) (*SetCardLimitsResponse, error)
 }
 
 type cardServiceClient struct {
@@ -95,6 +107,66 @@ func (c *cardServiceClient) CheckRecipientCard(ctx context.Context, in *CheckRec
 	return out, nil
 }
 
+func (c *cardServiceClient) BlockCard(ctx context.Context, in *BlockCardRequest, opts ...grpc.CallOption) (*BlockCardResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BlockCardResponse)
+	err := c.cc.Invoke(ctx, CardService_BlockCard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cardServiceClient) FreezeCard(ctx context.Context, in *FreezeCardRequest, opts ...grpc.CallOption) (*FreezeCardResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FreezeCardResponse)
+	err := c.cc.Invoke(ctx, CardService_FreezeCard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cardServiceClient) UnfreezeCard(ctx context.Context, in *UnfreezeCardRequest, opts ...grpc.CallOption) (*UnfreezeCardResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnfreezeCardResponse)
+	err := c.cc.Invoke(ctx, CardService_UnfreezeCard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cardServiceClient) ReissueCard(ctx context.Context, in *ReissueCardRequest, opts ...grpc.CallOption) (*ReissueCardResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReissueCardResponse)
+	err := c.cc.Invoke(ctx, CardService_ReissueCard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cardServiceClient) GetCardLimits(ctx context.Context, in *GetCardLimitsRequest, opts ...grpc.CallOption) (*GetCardLimitsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCardLimitsResponse)
+	err := c.cc.Invoke(ctx, CardService_GetCardLimits_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cardServiceClient) SetCardLimits(ctx context.Context, in *SetCardLimitsRequest, opts ...grpc.CallOption) (*SetCardLimitsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetCardLimitsResponse)
+	err := c.cc.Invoke(ctx, CardService_SetCardLimits_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CardServiceServer is the server API for CardService service.
 // All implementations must embed UnimplementedCardServiceServer
 // for forward compatibility.
@@ -104,6 +176,12 @@ type CardServiceServer interface {
 	ListCards(context.Context, *ListCardsRequest) (*ListCardsResponse, error)
 	DeleteCard(context.Context, *DeleteCardRequest) (*DeleteCardResponse, error)
 	CheckRecipientCard(context.Context, *CheckRecipientCardRequest) (*CheckRecipientCardResponse, error)
+	BlockCard(context.Context, *BlockCardRequest) (*BlockCardResponse, error)
+	FreezeCard(context.Context, *FreezeCardRequest) (*FreezeCardResponse, error)
+	UnfreezeCard(context.Context, *UnfreezeCardRequest) (*UnfreezeCardResponse, error)
+	ReissueCard(context.Context, *ReissueCardRequest) (*ReissueCardResponse, error)
+	GetCardLimits(context.Context, *GetCardLimitsRequest) (*GetCardLimitsResponse, error)
+	SetCardLimits(context.Context, *SetCardLimitsRequest) (*SetCardLimitsResponse, error)
 	mustEmbedUnimplementedCardServiceServer()
 }
 
@@ -129,6 +207,24 @@ func (UnimplementedCardServiceServer) DeleteCard(context.Context, *DeleteCardReq
 func (UnimplementedCardServiceServer) CheckRecipientCard(context.Context, *CheckRecipientCardRequest) (*CheckRecipientCardResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CheckRecipientCard not implemented")
 }
+func (UnimplementedCardServiceServer) BlockCard(context.Context, *BlockCardRequest) (*BlockCardResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BlockCard not implemented")
+}
+func (UnimplementedCardServiceServer) FreezeCard(context.Context, *FreezeCardRequest) (*FreezeCardResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FreezeCard not implemented")
+}
+func (UnimplementedCardServiceServer) UnfreezeCard(context.Context, *UnfreezeCardRequest) (*UnfreezeCardResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnfreezeCard not implemented")
+}
+func (UnimplementedCardServiceServer) ReissueCard(context.Context, *ReissueCardRequest) (*ReissueCardResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReissueCard not implemented")
+}
+func (UnimplementedCardServiceServer) GetCardLimits(context.Context, *GetCardLimitsRequest) (*GetCardLimitsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCardLimits not implemented")
+}
+func (UnimplementedCardServiceServer) SetCardLimits(context.Context, *SetCardLimitsRequest) (*SetCardLimitsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetCardLimits not implemented")
+}
 func (UnimplementedCardServiceServer) mustEmbedUnimplementedCardServiceServer() {}
 func (UnimplementedCardServiceServer) testEmbeddedByValue()                     {}
 
@@ -240,6 +336,114 @@ func _CardService_CheckRecipientCard_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CardService_BlockCard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockCardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CardServiceServer).BlockCard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CardService_BlockCard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CardServiceServer).BlockCard(ctx, req.(*BlockCardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CardService_FreezeCard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FreezeCardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CardServiceServer).FreezeCard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CardService_FreezeCard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CardServiceServer).FreezeCard(ctx, req.(*FreezeCardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CardService_UnfreezeCard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnfreezeCardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CardServiceServer).UnfreezeCard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CardService_UnfreezeCard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CardServiceServer).UnfreezeCard(ctx, req.(*UnfreezeCardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CardService_ReissueCard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReissueCardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CardServiceServer).ReissueCard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CardService_ReissueCard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CardServiceServer).ReissueCard(ctx, req.(*ReissueCardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CardService_GetCardLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCardLimitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CardServiceServer).GetCardLimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CardService_GetCardLimits_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CardServiceServer).GetCardLimits(ctx, req.(*GetCardLimitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CardService_SetCardLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCardLimitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CardServiceServer).SetCardLimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CardService_SetCardLimits_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CardServiceServer).SetCardLimits(ctx, req.(*SetCardLimitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CardService_ServiceDesc is the grpc.ServiceDesc for CardService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -267,6 +471,30 @@ var CardService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CheckRecipientCard",
 			Handler:    _CardService_CheckRecipientCard_Handler,
 		},
+		{
+			MethodName: "BlockCard",
+			Handler:    _CardService_BlockCard_Handler,
+		},
+		{
+			MethodName: "FreezeCard",
+			Handler:    _CardService_FreezeCard_Handler,
+		},
+		{
+			MethodName: "UnfreezeCard",
+			Handler:    _CardService_UnfreezeCard_Handler,
+		},
+		{
+			MethodName: "ReissueCard",
+			Handler:    _CardService_ReissueCard_Handler,
+		},
+		{
+			MethodName: "GetCardLimits",
+			Handler:    _CardService_GetCardLimits_Handler,
+		},
+		{
+			MethodName: "SetCardLimits",
+			Handler:    _CardService_SetCardLimits_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "cards_service.proto",
@@ -152,7 +152,7 @@ func MakeRedisReplicationServ() {
 	var usedMemory int64
 
 	// Выполняем запрос к PostgreSQL
-	rows, err := usfl.DB.Query("SELECT user_id, card_type, card_number, card_expiry_date, availability, username, balance FROM cards")
+	rows, err := usfl.DB.Query("SELECT user_id, card_type, card_number, expiry_month, expiry_year, status, username, balance, currency FROM cards")
 	if err != nil {
 		log.Printf("Ошибка при выполнении запроса GetCards: %v", err)
 		//
@@ -162,7 +162,7 @@ func MakeRedisReplicationServ() {
 
 	for rows.Next() {
 		var cardData models.Card
-		if err := rows.Scan(&cardData.UserID, &cardData.CardType, &cardData.CardNumber, &cardData.CardExpiryDate, &cardData.Availability, &cardData.Username, &cardData.Balance); err != nil {
+		if err := rows.Scan(&cardData.UserID, &cardData.CardType, &cardData.CardNumber, &cardData.ExpiryMonth, &cardData.ExpiryYear, &cardData.Status, &cardData.Username, &cardData.Balance, &cardData.Currency); err != nil {
 			log.Printf("Ошибка при сканировании строки GetCards: %v", err)
 			//
 
@@ -2,15 +2,28 @@ package balances
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
 	"log"
+	"math"
+	"time"
 
 	usfl "fin-trans/database_methods_package"
 	models "fin-trans/models_package"
 	cardpb "fin-trans/proto/proto_generated/cards_service"
+	"fin-trans/transactions_service/fx"
+	"fin-trans/transactions_service/history"
+	"fin-trans/transactions_service/limits"
 )
 
 func RefreshBalances(newTransaction models.FintransSuccessfulTransactionsPostgres, cardClient cardpb.CardServiceClient) {
 	ctx := context.Background()
+	// Пока сервис карт недоступен, перевод уходит в очередь без проверок. С отрицательной
+	// суммой списание с отправителя превратилось бы в списание с получателя.
+	if newTransaction.Amount <= 0 || math.IsInf(newTransaction.Amount, 0) || math.IsNaN(newTransaction.Amount) {
+		log.Printf("Отклонён перевод по карте %v: неверная сумма %v", newTransaction.CardNumber, newTransaction.Amount)
+		return
+	}
 	// Начинаем новую транзакцию
 	tx, err := usfl.DB.Begin()
 	if err != nil {
@@ -35,40 +48,105 @@ func RefreshBalances(newTransaction models.FintransSuccessfulTransactionsPostgre
 		return
 	}
 
-	if senderCard != nil {
-		// Проверяем, достаточно ли средств для отправки
-		if senderCard.Balance < newTransaction.Amount {
-			tx.Rollback()
-			log.Printf("Недостаточно средств, пополните баланс или воспользуйтесь другой картой: %v", err)
-			return
-		}
+	// Перевод мог попасть в очередь без проверки владельца, пока сервис карт был недоступен.
+	// Сервисному аккаунту scope transactions:create разрешает переводы с любых карт.
+	if newTransaction.SenderServiceAccountID == 0 && senderCard.UserId != newTransaction.SenderUserID {
+		tx.Rollback()
+		log.Printf("Откат транзакции: карта %v не принадлежит пользователю %d", newTransaction.CardNumber, newTransaction.SenderUserID)
+		return
 	}
-	if recipientCard != nil {
-		// Проверяем доступность получателя
-		if !recipientCard.Availability {
+
+	// Статус проверяется и при создании перевода, но карту могли заморозить, пока перевод был в очереди
+	if senderCard.Status != models.CardStatusActive {
+		tx.Rollback()
+		log.Printf("Откат транзакции: карта отправителя %v в статусе %s", newTransaction.CardNumber, senderCard.Status)
+		return
+	}
+	// Задача сервиса карт переводит истёкшие карты в EXPIRED не сразу, поэтому срок проверяется по дате
+	if models.CardExpired(int(senderCard.ExpiryMonth), int(senderCard.ExpiryYear), time.Now()) {
+		tx.Rollback()
+		log.Printf("Откат транзакции: истёк срок карты отправителя %v", newTransaction.CardNumber)
+		return
+	}
+	if recipientCard.Status != models.CardStatusActive {
+		tx.Rollback()
+		log.Printf("Откат транзакции: карта получателя %v в статусе %s", newTransaction.RecipientCardNumber, recipientCard.Status)
+		return
+	}
+
+	// Сумма перевода задаётся в валюте карты отправителя. Перевод без валюты
+	// создан, пока сервис карт был недоступен, и получает валюту карты.
+	if newTransaction.Currency == "" {
+		newTransaction.Currency = senderCard.Currency
+	}
+	if newTransaction.Currency != senderCard.Currency {
+		tx.Rollback()
+		log.Printf("Откат транзакции: валюта перевода %s не совпадает с валютой карты %v (%s)", newTransaction.Currency, newTransaction.CardNumber, senderCard.Currency)
+		return
+	}
+	newTransaction.TargetAmount = newTransaction.Amount
+	newTransaction.TargetCurrency = recipientCard.Currency
+	newTransaction.Rate = 1
+	if recipientCard.Currency != senderCard.Currency {
+		if err := applyQuote(ctx, tx, &newTransaction, senderCard.Currency, recipientCard.Currency); err != nil {
 			tx.Rollback()
-			log.Printf("Откат транзакции: карта недоступна %v", newTransaction.RecipientCardNumber)
+			log.Printf("Откат транзакции: %v", err)
 			return
 		}
+	} else {
+		// Котировка нужна только для конвертации и остаётся неиспользованной
+		newTransaction.QuoteID = ""
 	}
 
-	// Обновляем балансы пользователей
-	if _, err := tx.Exec("UPDATE cards SET balance = balance - $1 WHERE card_number = $2", newTransaction.Amount, senderCard.CardNumber); err != nil {
+	// Строка карты отправителя блокируется до конца транзакции, чтобы параллельные
+	// переводы с неё не прошли проверку баланса и лимиты, посчитав одно и то же.
+	// Баланс из GetCard мог устареть, поэтому он читается заново под блокировкой.
+	var senderBalance float64
+	if err := tx.QueryRowContext(ctx, "SELECT balance FROM cards WHERE card_number = $1 FOR UPDATE", newTransaction.CardNumber).Scan(&senderBalance); err != nil {
+		tx.Rollback()
+		log.Printf("Ошибка при блокировке карты отправителя: %v", err)
+		return
+	}
+	// Проверяем, достаточно ли средств для отправки
+	if senderBalance < newTransaction.Amount {
+		tx.Rollback()
+		log.Printf("Откат транзакции: недостаточно средств на карте %v", newTransaction.CardNumber)
+		return
+	}
+	if err := limits.Check(ctx, tx, newTransaction.CardNumber, newTransaction.Amount, time.Now()); err != nil {
+		tx.Rollback()
+		log.Printf("Откат транзакции по карте %v: %v", newTransaction.CardNumber, err)
+		return
+	}
+
+	// Обновляем балансы пользователей. Статус мог смениться после GetCard,
+	// поэтому неактивные карты отсекаются в самом UPDATE.
+	res, err := tx.Exec("UPDATE cards SET balance = balance - $1 WHERE card_number = $2 AND status = $3 AND balance >= $1", newTransaction.Amount, senderCard.CardNumber, models.CardStatusActive)
+	if err != nil {
 		tx.Rollback()
 		log.Println("Отмена транзакции (ошибка при обновлении баланса отправителя)")
 		return
 	}
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		tx.Rollback()
+		log.Printf("Откат транзакции: карта отправителя %v больше не активна или на ней недостаточно средств", newTransaction.CardNumber)
+		return
+	}
 
-	if _, err := tx.Exec("UPDATE cards SET balance = balance + $1 WHERE card_number = $2", newTransaction.Amount, recipientCard.CardNumber); err != nil {
+	res, err = tx.Exec("UPDATE cards SET balance = balance + $1 WHERE card_number = $2 AND status = $3", newTransaction.TargetAmount, recipientCard.CardNumber, models.CardStatusActive)
+	if err != nil {
 		tx.Rollback()
 		log.Printf("Ошибка при обновлении баланса получателя: %v", err)
 		return
 	}
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		tx.Rollback()
+		log.Printf("Откат транзакции: карта получателя %v больше не активна", newTransaction.RecipientCardNumber)
+		return
+	}
 
 	// Сохраняем информацию о транзакции в БД
-	_, err = tx.Exec("INSERT INTO fintrans_successful_transactions_postgres (card_number, recipient_card_number, amount) VALUES ($1, $2, $3)",
-		newTransaction.CardNumber, newTransaction.RecipientCardNumber, newTransaction.Amount)
-	if err != nil {
+	if err := history.Record(ctx, tx, newTransaction); err != nil {
 		tx.Rollback()
 		log.Printf("Ошибка при сохранении транзакции в БД: %v", err)
 		return
@@ -82,3 +160,28 @@ func RefreshBalances(newTransaction models.FintransSuccessfulTransactionsPostgre
 
 	log.Println("Транзакция прошла успешно")
 }
+
+// applyQuote пересчитывает сумму для получателя в валюте to по курсу котировки перевода.
+// Котировку, не отмеченную при создании перевода, пока сервис карт был недоступен,
+// отмечает сама; второй перевод по той же котировке отсечёт уникальный индекс истории.
+func applyQuote(ctx context.Context, tx *sql.Tx, t *models.FintransSuccessfulTransactionsPostgres, from, to string) error {
+	if t.QuoteID == "" {
+		return fmt.Errorf("карта получателя %v в валюте %s, а не %s, котировка не передана", t.RecipientCardNumber, to, from)
+	}
+	owner := fx.Owner(t.SenderUserID, t.SenderServiceAccountID)
+	quote, err := fx.Load(ctx, tx, t.QuoteID)
+	if err != nil {
+		return fmt.Errorf("котировка %s: %w", t.QuoteID, err)
+	}
+	if quote.Owner != owner || quote.FromCurrency != from || quote.ToCurrency != to {
+		return fmt.Errorf("котировка %s выписана не для этого перевода", t.QuoteID)
+	}
+	if quote.UsedAt == nil {
+		if _, err := fx.Claim(ctx, tx, t.QuoteID, owner); err != nil {
+			return fmt.Errorf("котировка %s: %w", t.QuoteID, err)
+		}
+	}
+	t.Rate = quote.Rate
+	t.TargetAmount = fx.Convert(t.Amount, quote.Rate)
+	return nil
+}
@@ -0,0 +1,108 @@
+package balances
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	usfl "fin-trans/database_methods_package"
+	models "fin-trans/models_package"
+	"fin-trans/transactions_service/history"
+	"fin-trans/transactions_service/limits"
+
+	"github.com/lib/pq"
+)
+
+const pqUniqueViolation = "23505"
+
+// ErrAlreadyApplied - операция с этим внешним идентификатором уже проведена,
+// например RabbitMQ доставил сообщение повторно
+var ErrAlreadyApplied = errors.New("операция уже проведена")
+
+// ApplyFunds проводит пополнение или списание из очереди: меняет баланс карты и
+// записывает операцию в историю в одной транзакции. Пополнение зачисляется на
+// RecipientCardNumber, списание снимается с CardNumber. Ошибка означает, что
+// операция отклонена и баланс не изменился, кроме ErrAlreadyApplied.
+func ApplyFunds(t models.FintransSuccessfulTransactionsPostgres) error {
+	ctx := context.Background()
+	number, sign := t.RecipientCardNumber, 1.0
+	switch t.Kind {
+	case models.OperationDeposit:
+	case models.OperationWithdrawal:
+		number, sign = t.CardNumber, -1
+	default:
+		return fmt.Errorf("неизвестный вид операции %q", t.Kind)
+	}
+	if t.Amount <= 0 {
+		return fmt.Errorf("неверная сумма операции %v", t.Amount)
+	}
+
+	tx, err := usfl.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("ошибка при начале транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Строка карты блокируется до конца транзакции вместе с балансом и лимитами
+	var card models.Card
+	err = tx.QueryRowContext(ctx, "SELECT status, expiry_month, expiry_year, currency, balance FROM cards WHERE card_number = $1 FOR UPDATE", number).
+		Scan(&card.Status, &card.ExpiryMonth, &card.ExpiryYear, &card.Currency, &card.Balance)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("карта %v не найдена", number)
+	}
+	if err != nil {
+		return fmt.Errorf("ошибка при получении карты %v: %w", number, err)
+	}
+	// Проверка до лимитов и баланса: повтор уже проведённого списания не должен отклоняться
+	// из-за того, что его сумма уже снята
+	var applied bool
+	err = tx.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM fintrans_successful_transactions_postgres WHERE service_account_id = $1 AND kind = $2 AND external_ref = $3)",
+		t.SenderServiceAccountID, t.Kind, t.ExternalRef).Scan(&applied)
+	if err != nil {
+		return fmt.Errorf("ошибка при проверке операции %s: %w", t.ExternalRef, err)
+	}
+	if applied {
+		return ErrAlreadyApplied
+	}
+	if card.Status != models.CardStatusActive {
+		return fmt.Errorf("карта %v в статусе %s", number, card.Status)
+	}
+	if models.CardExpired(card.ExpiryMonth, card.ExpiryYear, time.Now()) {
+		return fmt.Errorf("истёк срок карты %v", number)
+	}
+	// Операция без валюты проводится в валюте карты, конвертации нет
+	if t.Currency == "" {
+		t.Currency = card.Currency
+	}
+	if t.Currency != card.Currency {
+		return fmt.Errorf("валюта операции %s не совпадает с валютой карты %v (%s)", t.Currency, number, card.Currency)
+	}
+
+	if t.Kind == models.OperationWithdrawal {
+		if card.Balance < t.Amount {
+			return fmt.Errorf("недостаточно средств на карте %v", number)
+		}
+		if err := limits.Check(ctx, tx, number, t.Amount, time.Now()); err != nil {
+			return fmt.Errorf("карта %v: %w", number, err)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, "UPDATE cards SET balance = balance + $1 WHERE card_number = $2", sign*t.Amount, number); err != nil {
+		return fmt.Errorf("ошибка при обновлении баланса карты %v: %w", number, err)
+	}
+	// Повтор, проведённый параллельно по другой карте, отсечёт уникальный индекс истории
+	t.QuoteID = ""
+	if err := history.Record(ctx, tx, t); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == pqUniqueViolation {
+			return ErrAlreadyApplied
+		}
+		return fmt.Errorf("ошибка при сохранении операции в БД: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("ошибка при подтверждении транзакции: %w", err)
+	}
+	return nil
+}
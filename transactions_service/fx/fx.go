@@ -0,0 +1,92 @@
+// Package fx конвертирует суммы переводов между валютами карт. Курс берётся у
+// RateProvider и фиксируется в котировке на короткое время: перевод проводится
+// по курсу котировки, даже если курс провайдера успел измениться.
+package fx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+)
+
+// RatesFileEnv - JSON-файл с курсами вида {"USD/RUB": 90.5, "EUR/USD": 1.08}
+const RatesFileEnv = "FX_RATES_FILE"
+
+// DefaultRates используются для локального запуска, если RatesFileEnv не задана
+var DefaultRates = map[string]float64{
+	"USD/RUB": 90,
+	"EUR/RUB": 98,
+	"EUR/USD": 1.09,
+}
+
+var ErrUnsupportedPair = errors.New("нет курса для этой пары валют")
+
+// RateProvider - источник курсов. Rate возвращает, сколько единиц to стоит одна единица from.
+type RateProvider interface {
+	Rate(ctx context.Context, from, to string) (float64, error)
+}
+
+// StaticProvider - неизменные курсы из настроек или файла. Обратный курс
+// вычисляется, если он не задан явно.
+type StaticProvider struct {
+	rates map[string]float64
+}
+
+// NewStaticProvider проверяет курсы вида "FROM/TO": rate
+func NewStaticProvider(rates map[string]float64) (*StaticProvider, error) {
+	p := &StaticProvider{rates: make(map[string]float64, len(rates))}
+	for pair, rate := range rates {
+		from, to, ok := strings.Cut(strings.ToUpper(pair), "/")
+		if !ok || len(from) != 3 || len(to) != 3 || from == to {
+			return nil, fmt.Errorf("неверная пара валют %q", pair)
+		}
+		if rate <= 0 || math.IsInf(rate, 0) || math.IsNaN(rate) {
+			return nil, fmt.Errorf("неверный курс %s: %v", pair, rate)
+		}
+		p.rates[from+"/"+to] = rate
+	}
+	return p, nil
+}
+
+// LoadRatesFile читает курсы из JSON-файла
+func LoadRatesFile(path string) (*StaticProvider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var rates map[string]float64
+	if err := json.Unmarshal(data, &rates); err != nil {
+		return nil, fmt.Errorf("не удалось разобрать %s: %w", path, err)
+	}
+	return NewStaticProvider(rates)
+}
+
+// ProviderFromEnv - курсы из RatesFileEnv или DefaultRates
+func ProviderFromEnv() (RateProvider, error) {
+	if path := os.Getenv(RatesFileEnv); path != "" {
+		return LoadRatesFile(path)
+	}
+	return NewStaticProvider(DefaultRates)
+}
+
+func (p *StaticProvider) Rate(ctx context.Context, from, to string) (float64, error) {
+	if from == to {
+		return 1, nil
+	}
+	if rate, ok := p.rates[from+"/"+to]; ok {
+		return rate, nil
+	}
+	if rate, ok := p.rates[to+"/"+from]; ok {
+		return 1 / rate, nil
+	}
+	return 0, ErrUnsupportedPair
+}
+
+// Convert переводит amount по курсу rate с округлением до копеек
+func Convert(amount, rate float64) float64 {
+	return math.Round(amount*rate*100) / 100
+}
@@ -0,0 +1,108 @@
+package fx
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	usfl "fin-trans/database_methods_package"
+	models "fin-trans/models_package"
+)
+
+var ErrQuoteNotFound = errors.New("котировка не найдена, истекла или уже использована")
+
+const schema = `
+CREATE TABLE IF NOT EXISTS fx_quotes (
+	id            TEXT PRIMARY KEY,
+	owner         TEXT NOT NULL,
+	from_currency TEXT NOT NULL,
+	to_currency   TEXT NOT NULL,
+	rate          DOUBLE PRECISION NOT NULL,
+	created_at    TIMESTAMPTZ NOT NULL,
+	expires_at    TIMESTAMPTZ NOT NULL,
+	used_at       TIMESTAMPTZ
+)`
+
+const columns = "id, owner, from_currency, to_currency, rate, created_at, expires_at, used_at"
+
+// Querier - *sql.DB или *sql.Tx
+type Querier interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// Owner - владелец котировки: сервисный аккаунт или пользователь
+func Owner(userID, serviceAccountID int32) string {
+	if serviceAccountID != 0 {
+		return fmt.Sprintf("service_account:%d", serviceAccountID)
+	}
+	return fmt.Sprintf("user:%d", userID)
+}
+
+// EnsureSchema создаёт таблицу котировок при старте сервиса
+func EnsureSchema() error {
+	if usfl.DB == nil {
+		return errors.New("нет подключения к БД")
+	}
+	if _, err := usfl.DB.Exec(schema); err != nil {
+		return fmt.Errorf("не удалось создать таблицу fx_quotes: %w", err)
+	}
+	return nil
+}
+
+// NewQuote берёт курс from -> to у провайдера и фиксирует его для owner на ttl
+func NewQuote(ctx context.Context, p RateProvider, owner, from, to string, ttl time.Duration) (*models.FxQuote, error) {
+	rate, err := p.Rate(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	idBytes := make([]byte, 16)
+	if _, err := rand.Read(idBytes); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	q := &models.FxQuote{
+		ID:           hex.EncodeToString(idBytes),
+		Owner:        owner,
+		FromCurrency: from,
+		ToCurrency:   to,
+		Rate:         rate,
+		CreatedAt:    now,
+		ExpiresAt:    now.Add(ttl),
+	}
+	_, err = usfl.DB.ExecContext(ctx, "INSERT INTO fx_quotes ("+columns+") VALUES ($1, $2, $3, $4, $5, $6, $7, $8)",
+		q.ID, q.Owner, q.FromCurrency, q.ToCurrency, q.Rate, q.CreatedAt, q.ExpiresAt, q.UsedAt)
+	if err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// Load возвращает котировку в любом состоянии
+func Load(ctx context.Context, db Querier, id string) (*models.FxQuote, error) {
+	return scan(db.QueryRowContext(ctx, "SELECT "+columns+" FROM fx_quotes WHERE id = $1", id))
+}
+
+// Claim отмечает котировку owner использованной. Возвращает ErrQuoteNotFound,
+// если котировка чужая, истекла или уже оплатила другой перевод.
+func Claim(ctx context.Context, db Querier, id, owner string) (*models.FxQuote, error) {
+	return scan(db.QueryRowContext(ctx, `UPDATE fx_quotes SET used_at = now()
+		WHERE id = $1 AND owner = $2 AND used_at IS NULL AND expires_at > now()
+		RETURNING `+columns, id, owner))
+}
+
+func scan(row *sql.Row) (*models.FxQuote, error) {
+	var q models.FxQuote
+	err := row.Scan(&q.ID, &q.Owner, &q.FromCurrency, &q.ToCurrency, &q.Rate, &q.CreatedAt, &q.ExpiresAt, &q.UsedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrQuoteNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &q, nil
+}
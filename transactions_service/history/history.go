@@ -0,0 +1,68 @@
+// Package history записывает проведённые переводы в таблицу
+// fintrans_successful_transactions_postgres. По ней строится история карты
+// и считаются лимиты расходов.
+package history
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	usfl "fin-trans/database_methods_package"
+	models "fin-trans/models_package"
+)
+
+// Переводы, проведённые до появления created_at, остаются без даты и в лимиты не входят.
+// До появления валют все карты были рублёвыми. amount и currency - сумма, списанная с
+// карты отправителя, target_amount и target_currency - зачисленная получателю по курсу rate.
+// Котировкой можно оплатить только один перевод. kind отличает переводы от пополнений
+// и списаний, внешний идентификатор пополнения или списания проводится один раз для
+// каждого сервисного аккаунта.
+const schema = `
+ALTER TABLE fintrans_successful_transactions_postgres ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ;
+ALTER TABLE fintrans_successful_transactions_postgres ALTER COLUMN created_at SET DEFAULT now();
+ALTER TABLE fintrans_successful_transactions_postgres ADD COLUMN IF NOT EXISTS currency TEXT NOT NULL DEFAULT 'RUB';
+ALTER TABLE fintrans_successful_transactions_postgres ADD COLUMN IF NOT EXISTS target_amount DOUBLE PRECISION;
+ALTER TABLE fintrans_successful_transactions_postgres ADD COLUMN IF NOT EXISTS target_currency TEXT;
+ALTER TABLE fintrans_successful_transactions_postgres ADD COLUMN IF NOT EXISTS rate DOUBLE PRECISION;
+ALTER TABLE fintrans_successful_transactions_postgres ADD COLUMN IF NOT EXISTS quote_id TEXT;
+ALTER TABLE fintrans_successful_transactions_postgres ADD COLUMN IF NOT EXISTS kind TEXT NOT NULL DEFAULT 'TRANSFER';
+ALTER TABLE fintrans_successful_transactions_postgres ADD COLUMN IF NOT EXISTS external_ref TEXT;
+ALTER TABLE fintrans_successful_transactions_postgres ADD COLUMN IF NOT EXISTS service_account_id INTEGER NOT NULL DEFAULT 0;
+DROP INDEX IF EXISTS fintrans_successful_transactions_external_ref_idx;
+CREATE UNIQUE INDEX IF NOT EXISTS fintrans_successful_transactions_service_external_ref_idx ON fintrans_successful_transactions_postgres (service_account_id, kind, external_ref) WHERE external_ref IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS fintrans_successful_transactions_quote_idx ON fintrans_successful_transactions_postgres (quote_id) WHERE quote_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS fintrans_successful_transactions_card_created_idx ON fintrans_successful_transactions_postgres (card_number, created_at)`
+
+// Execer - *sql.DB или *sql.Tx
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// EnsureSchema добавляет в таблицу переводов дату, валюты, курс и вид операции
+func EnsureSchema() error {
+	if usfl.DB == nil {
+		return errors.New("нет подключения к БД")
+	}
+	if _, err := usfl.DB.Exec(schema); err != nil {
+		return fmt.Errorf("не удалось обновить таблицу переводов: %w", err)
+	}
+	return nil
+}
+
+// Record сохраняет проведённую операцию. Вызывается в той же транзакции, что меняет балансы.
+// Перевод без конвертации зачисляется получателю в той же сумме по курсу 1.
+func Record(ctx context.Context, e Execer, t models.FintransSuccessfulTransactionsPostgres) error {
+	if t.TargetCurrency == "" {
+		t.TargetAmount, t.TargetCurrency, t.Rate = t.Amount, t.Currency, 1
+	}
+	if t.Kind == "" {
+		t.Kind = models.OperationTransfer
+	}
+	_, err := e.ExecContext(ctx, `INSERT INTO fintrans_successful_transactions_postgres
+		(kind, card_number, recipient_card_number, amount, currency, target_amount, target_currency, rate, quote_id, external_ref, service_account_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, ''), NULLIF($10, ''), $11)`,
+		t.Kind, t.CardNumber, t.RecipientCardNumber, t.Amount, t.Currency, t.TargetAmount, t.TargetCurrency, t.Rate, t.QuoteID, t.ExternalRef, t.SenderServiceAccountID)
+	return err
+}
@@ -0,0 +1,241 @@
+// Package limits ограничивает расходы по карте: сумму одного перевода, сумму и
+// число переводов за день и сумму за месяц. Лимиты задаются в валюте карты,
+// потраченное считается по истории переводов из пакета history.
+package limits
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	usfl "fin-trans/database_methods_package"
+	models "fin-trans/models_package"
+)
+
+var ErrCardNotFound = errors.New("карта не найдена")
+
+const schema = `
+CREATE TABLE IF NOT EXISTS card_limits (
+	card_number     TEXT PRIMARY KEY,
+	per_transaction DOUBLE PRECISION NOT NULL,
+	daily           DOUBLE PRECISION NOT NULL,
+	monthly         DOUBLE PRECISION NOT NULL,
+	daily_count     INTEGER NOT NULL,
+	updated_by      TEXT NOT NULL,
+	updated_at      TIMESTAMPTZ NOT NULL
+)`
+
+// Limits - лимиты карты. Дни и месяцы считаются по UTC.
+type Limits struct {
+	PerTransaction float64
+	Daily          float64
+	Monthly        float64
+	DailyCount     int
+}
+
+// Usage - сколько с карты уже отправлено за текущие день и месяц
+type Usage struct {
+	Daily      float64
+	Monthly    float64
+	DailyCount int
+}
+
+// Defaults - лимиты по умолчанию для типов карт в каждой валюте. Лимиты задаются
+// в валюте карты, поэтому одно число для всех валют дало бы долларовой карте
+// лимиты в десятки раз выше рублёвой. Выше них лимиты поднимает только оператор.
+var Defaults = map[string]map[string]Limits{
+	models.CurrencyRUB: {
+		"debit":  {PerTransaction: 150000, Daily: 300000, Monthly: 1500000, DailyCount: 50},
+		"credit": {PerTransaction: 100000, Daily: 200000, Monthly: 1000000, DailyCount: 30},
+	},
+	models.CurrencyUSD: {
+		"debit":  {PerTransaction: 1500, Daily: 3000, Monthly: 15000, DailyCount: 50},
+		"credit": {PerTransaction: 1000, Daily: 2000, Monthly: 10000, DailyCount: 30},
+	},
+	models.CurrencyEUR: {
+		"debit":  {PerTransaction: 1500, Daily: 3000, Monthly: 15000, DailyCount: 50},
+		"credit": {PerTransaction: 1000, Daily: 2000, Monthly: 10000, DailyCount: 30},
+	},
+}
+
+// Fallback - лимиты в каждой валюте для карт типа, которого нет в Defaults
+var Fallback = map[string]Limits{
+	models.CurrencyRUB: {PerTransaction: 50000, Daily: 100000, Monthly: 500000, DailyCount: 20},
+	models.CurrencyUSD: {PerTransaction: 500, Daily: 1000, Monthly: 5000, DailyCount: 20},
+	models.CurrencyEUR: {PerTransaction: 500, Daily: 1000, Monthly: 5000, DailyCount: 20},
+}
+
+// ForType - лимиты по умолчанию для типа карты в валюте currency. Тип не зависит
+// от регистра. Карта без валюты выпущена до появления валют и считается рублёвой.
+func ForType(cardType, currency string) Limits {
+	if currency == "" {
+		currency = models.DefaultCurrency
+	}
+	if l, ok := Defaults[currency][strings.ToLower(cardType)]; ok {
+		return l
+	}
+	if l, ok := Fallback[currency]; ok {
+		return l
+	}
+	// Неизвестная валюта: наименьшие по величине лимиты, какой бы дорогой она ни была
+	strictest := Fallback[models.DefaultCurrency]
+	for _, l := range Fallback {
+		if l.Monthly < strictest.Monthly {
+			strictest = l
+		}
+	}
+	return strictest
+}
+
+// ExceededError - перевод превысил один из лимитов. Remaining - сколько ещё можно
+// отправить в рамках этого лимита.
+type ExceededError struct {
+	Limit     string
+	Remaining float64
+}
+
+func (e *ExceededError) Error() string {
+	return fmt.Sprintf("Превышен %s по карте, доступно %.2f", e.Limit, e.Remaining)
+}
+
+// Validate - все лимиты положительны и согласованы между собой
+func (l Limits) Validate() error {
+	if l.PerTransaction <= 0 || l.Daily <= 0 || l.Monthly <= 0 || l.DailyCount <= 0 {
+		return errors.New("лимиты должны быть положительными")
+	}
+	if l.PerTransaction > l.Daily || l.Daily > l.Monthly {
+		return errors.New("лимит перевода не может быть больше дневного, дневной - больше месячного")
+	}
+	return nil
+}
+
+// Within - ни один лимит l не выше соответствующего лимита max
+func (l Limits) Within(max Limits) bool {
+	return l.PerTransaction <= max.PerTransaction && l.Daily <= max.Daily &&
+		l.Monthly <= max.Monthly && l.DailyCount <= max.DailyCount
+}
+
+// Check - можно ли отправить amount при уже потраченном u
+func (l Limits) Check(u Usage, amount float64) error {
+	switch {
+	case amount > l.PerTransaction:
+		return &ExceededError{Limit: "лимит одного перевода", Remaining: l.PerTransaction}
+	case u.DailyCount >= l.DailyCount:
+		return &ExceededError{Limit: "дневной лимит числа переводов", Remaining: 0}
+	case u.Daily+amount > l.Daily:
+		return &ExceededError{Limit: "дневной лимит", Remaining: max(l.Daily-u.Daily, 0)}
+	case u.Monthly+amount > l.Monthly:
+		return &ExceededError{Limit: "месячный лимит", Remaining: max(l.Monthly-u.Monthly, 0)}
+	}
+	return nil
+}
+
+// Querier - *sql.DB или *sql.Tx
+type Querier interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// EnsureSchema создаёт таблицу лимитов
+func EnsureSchema() error {
+	if usfl.DB == nil {
+		return errors.New("нет подключения к БД")
+	}
+	if _, err := usfl.DB.Exec(schema); err != nil {
+		return fmt.Errorf("не удалось создать таблицу card_limits: %w", err)
+	}
+	return nil
+}
+
+// Load возвращает лимиты карты и признак того, что они заданы для неё, а не взяты по типу
+func Load(ctx context.Context, q Querier, cardNumber string) (Limits, bool, error) {
+	var cardType, currency string
+	var perTransaction, daily, monthly sql.NullFloat64
+	var dailyCount sql.NullInt64
+	err := q.QueryRowContext(ctx, `SELECT c.card_type, c.currency, l.per_transaction, l.daily, l.monthly, l.daily_count
+		FROM cards c LEFT JOIN card_limits l ON l.card_number = c.card_number WHERE c.card_number = $1`, cardNumber).
+		Scan(&cardType, &currency, &perTransaction, &daily, &monthly, &dailyCount)
+	if err == sql.ErrNoRows {
+		return Limits{}, false, ErrCardNotFound
+	}
+	if err != nil {
+		return Limits{}, false, err
+	}
+	if !perTransaction.Valid {
+		return ForType(cardType, currency), false, nil
+	}
+	return Limits{
+		PerTransaction: perTransaction.Float64,
+		Daily:          daily.Float64,
+		Monthly:        monthly.Float64,
+		DailyCount:     int(dailyCount.Int64),
+	}, true, nil
+}
+
+// Save задаёт карте собственные лимиты
+func Save(ctx context.Context, q Querier, cardNumber string, l Limits, actor string, at time.Time) error {
+	_, err := q.ExecContext(ctx, `INSERT INTO card_limits (card_number, per_transaction, daily, monthly, daily_count, updated_by, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (card_number) DO UPDATE SET per_transaction = $2, daily = $3, monthly = $4, daily_count = $5, updated_by = $6, updated_at = $7`,
+		cardNumber, l.PerTransaction, l.Daily, l.Monthly, l.DailyCount, actor, at)
+	return err
+}
+
+// windows - начало дня и месяца по UTC, в которые попадает now
+func windows(now time.Time) (day, month time.Time) {
+	now = now.UTC()
+	day = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	month = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return day, month
+}
+
+// CopyToReissued переносит собственные лимиты карты from на выпущенную взамен неё карту to,
+// иначе перевыпуск сбрасывал бы сниженные лимиты к значениям по умолчанию
+func CopyToReissued(ctx context.Context, q Querier, from, to, actor string, at time.Time) error {
+	_, err := q.ExecContext(ctx, `INSERT INTO card_limits (card_number, per_transaction, daily, monthly, daily_count, updated_by, updated_at)
+		SELECT $2, per_transaction, daily, monthly, daily_count, $3, $4 FROM card_limits WHERE card_number = $1
+		ON CONFLICT (card_number) DO NOTHING`, from, to, actor, at)
+	return err
+}
+
+// Used считает отправленное с карты с начала дня и месяца, в которые попадает now.
+// Учитываются и карты, которые она заменила при перевыпуске, чтобы перевыпуск не
+// обнулял потраченное. Перенос остатка между ними расходом не считается.
+func Used(ctx context.Context, q Querier, cardNumber string, now time.Time) (Usage, error) {
+	day, month := windows(now)
+
+	var u Usage
+	err := q.QueryRowContext(ctx, `WITH RECURSIVE chain (card_number) AS (
+			SELECT $1::TEXT
+			UNION
+			SELECT c.replaces FROM cards c JOIN chain ON c.card_number = chain.card_number
+			WHERE c.replaces IS NOT NULL AND c.replaces <> ''
+		)
+		SELECT
+			COALESCE(SUM(amount) FILTER (WHERE created_at >= $2), 0),
+			COALESCE(SUM(amount), 0),
+			COUNT(*) FILTER (WHERE created_at >= $2)
+		FROM fintrans_successful_transactions_postgres
+		WHERE card_number IN (SELECT card_number FROM chain)
+			AND recipient_card_number NOT IN (SELECT card_number FROM chain)
+			AND created_at >= $3`, cardNumber, day, month).
+		Scan(&u.Daily, &u.Monthly, &u.DailyCount)
+	return u, err
+}
+
+// Check проверяет, что перевод amount с карты укладывается в её лимиты.
+// Превышение возвращается как *ExceededError.
+func Check(ctx context.Context, q Querier, cardNumber string, amount float64, now time.Time) error {
+	l, _, err := Load(ctx, q, cardNumber)
+	if err != nil {
+		return err
+	}
+	u, err := Used(ctx, q, cardNumber, now)
+	if err != nil {
+		return err
+	}
+	return l.Check(u, amount)
+}
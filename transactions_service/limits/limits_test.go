@@ -0,0 +1,128 @@
+package limits
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	models "fin-trans/models_package"
+)
+
+func TestCheck(t *testing.T) {
+	l := Limits{PerTransaction: 100, Daily: 300, Monthly: 1000, DailyCount: 3}
+	tests := []struct {
+		name      string
+		usage     Usage
+		amount    float64
+		limit     string // пусто - перевод проходит
+		remaining float64
+	}{
+		{"fits", Usage{}, 100, "", 0},
+		{"per transaction", Usage{}, 100.01, "лимит одного перевода", 100},
+		{"daily count", Usage{Daily: 10, Monthly: 10, DailyCount: 3}, 1, "дневной лимит числа переводов", 0},
+		{"daily exactly reached", Usage{Daily: 200, Monthly: 200, DailyCount: 2}, 100, "", 0},
+		{"daily", Usage{Daily: 250, Monthly: 250, DailyCount: 2}, 60, "дневной лимит", 50},
+		{"daily already over", Usage{Daily: 350, Monthly: 350, DailyCount: 2}, 10, "дневной лимит", 0},
+		{"monthly", Usage{Daily: 0, Monthly: 950, DailyCount: 0}, 80, "месячный лимит", 50},
+		{"monthly exactly reached", Usage{Monthly: 900}, 100, "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := l.Check(tt.usage, tt.amount)
+			if tt.limit == "" {
+				if err != nil {
+					t.Fatalf("Check() = %v, want nil", err)
+				}
+				return
+			}
+			var exceeded *ExceededError
+			if !errors.As(err, &exceeded) {
+				t.Fatalf("Check() = %v, want *ExceededError", err)
+			}
+			if exceeded.Limit != tt.limit || exceeded.Remaining != tt.remaining {
+				t.Fatalf("Check() = %+v, want limit %q remaining %v", exceeded, tt.limit, tt.remaining)
+			}
+		})
+	}
+}
+
+func TestValidateAndWithin(t *testing.T) {
+	max := Limits{PerTransaction: 100, Daily: 300, Monthly: 1000, DailyCount: 3}
+	tests := []struct {
+		name   string
+		l      Limits
+		valid  bool
+		within bool
+	}{
+		{"defaults", max, true, true},
+		{"lowered", Limits{PerTransaction: 50, Daily: 50, Monthly: 50, DailyCount: 1}, true, true},
+		{"raised count", Limits{PerTransaction: 100, Daily: 300, Monthly: 1000, DailyCount: 4}, true, false},
+		{"raised monthly", Limits{PerTransaction: 100, Daily: 300, Monthly: 1000.01, DailyCount: 3}, true, false},
+		{"zero", Limits{PerTransaction: 0, Daily: 300, Monthly: 1000, DailyCount: 3}, false, true},
+		{"per transaction above daily", Limits{PerTransaction: 301, Daily: 300, Monthly: 1000, DailyCount: 3}, false, false},
+		{"daily above monthly", Limits{PerTransaction: 100, Daily: 1001, Monthly: 1000, DailyCount: 3}, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.l.Validate(); (err == nil) != tt.valid {
+				t.Errorf("Validate() = %v, want valid %v", err, tt.valid)
+			}
+			if got := tt.l.Within(max); got != tt.within {
+				t.Errorf("Within() = %v, want %v", got, tt.within)
+			}
+		})
+	}
+}
+
+func TestForType(t *testing.T) {
+	tests := []struct {
+		cardType, currency string
+		want               Limits
+	}{
+		{"debit", models.CurrencyRUB, Defaults[models.CurrencyRUB]["debit"]},
+		{"DEBIT", models.CurrencyUSD, Defaults[models.CurrencyUSD]["debit"]},
+		{"credit", "", Defaults[models.CurrencyRUB]["credit"]},
+		{"prepaid", models.CurrencyEUR, Fallback[models.CurrencyEUR]},
+		{"debit", "GBP", Fallback[models.CurrencyUSD]},
+	}
+	for _, tt := range tests {
+		if got := ForType(tt.cardType, tt.currency); got != tt.want {
+			t.Errorf("ForType(%q, %q) = %+v, want %+v", tt.cardType, tt.currency, got, tt.want)
+		}
+	}
+	for currency, types := range Defaults {
+		for cardType, l := range types {
+			if err := l.Validate(); err != nil {
+				t.Errorf("Defaults[%s][%s]: %v", currency, cardType, err)
+			}
+		}
+	}
+}
+
+func TestWindows(t *testing.T) {
+	msk := time.FixedZone("MSK", 3*60*60)
+	tests := []struct {
+		name       string
+		now        time.Time
+		day, month time.Time
+	}{
+		{"midday", time.Date(2026, 3, 15, 12, 30, 0, 0, time.UTC),
+			time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"midnight belongs to the new day", time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC),
+			time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"local time is converted to UTC", time.Date(2026, 4, 1, 1, 0, 0, 0, msk),
+			time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"new year", time.Date(2027, 1, 1, 0, 0, 1, 0, time.UTC),
+			time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"leap day", time.Date(2028, 2, 29, 23, 59, 59, 0, time.UTC),
+			time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC), time.Date(2028, 2, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			day, month := windows(tt.now)
+			if !day.Equal(tt.day) || !month.Equal(tt.month) {
+				t.Fatalf("windows(%v) = %v, %v, want %v, %v", tt.now, day, month, tt.day, tt.month)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+// Package pending хранит в таблице pending_transactions переводы, ещё не проведённые
+// по балансам: ожидающие подтверждения одноразовым кодом и стоящие в очереди RabbitMQ.
+// Там же лежат пополнения и списания: внешний идентификатор операции уникален для
+// её вида и сервисного аккаунта, поэтому повторный запрос внешней системы находит
+// уже принятую операцию, а одинаковые идентификаторы разных партнёров не пересекаются.
+package pending
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	usfl "fin-trans/database_methods_package"
+	models "fin-trans/models_package"
+)
+
+var ErrNotFound = errors.New("перевод, ожидающий подтверждения, не найден")
+
+const schema = `
+CREATE TABLE IF NOT EXISTS pending_transactions (
+	id                    TEXT PRIMARY KEY,
+	card_number           TEXT NOT NULL,
+	amount                DOUBLE PRECISION NOT NULL,
+	recipient_card_number TEXT NOT NULL,
+	user_id               INTEGER NOT NULL,
+	challenge_id          TEXT NOT NULL,
+	status                TEXT NOT NULL,
+	created_at            TIMESTAMPTZ NOT NULL,
+	expires_at            TIMESTAMPTZ NOT NULL
+);
+ALTER TABLE pending_transactions ADD COLUMN IF NOT EXISTS currency TEXT NOT NULL DEFAULT '';
+ALTER TABLE pending_transactions ADD COLUMN IF NOT EXISTS convert BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE pending_transactions ADD COLUMN IF NOT EXISTS quote_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE pending_transactions ADD COLUMN IF NOT EXISTS kind TEXT NOT NULL DEFAULT 'TRANSFER';
+ALTER TABLE pending_transactions ADD COLUMN IF NOT EXISTS external_ref TEXT NOT NULL DEFAULT '';
+ALTER TABLE pending_transactions ADD COLUMN IF NOT EXISTS service_account_id INTEGER NOT NULL DEFAULT 0;
+DROP INDEX IF EXISTS pending_transactions_external_ref_idx;
+CREATE UNIQUE INDEX IF NOT EXISTS pending_transactions_service_external_ref_idx ON pending_transactions (service_account_id, kind, external_ref) WHERE external_ref <> '';
+CREATE INDEX IF NOT EXISTS pending_transactions_status_idx ON pending_transactions (status, expires_at);
+CREATE INDEX IF NOT EXISTS pending_transactions_card_idx ON pending_transactions (card_number, status);
+CREATE INDEX IF NOT EXISTS pending_transactions_recipient_idx ON pending_transactions (recipient_card_number, status)`
+
+// Querier - *sql.DB или *sql.Tx
+type Querier interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+const columns = "id, kind, external_ref, card_number, amount, recipient_card_number, currency, convert, quote_id, user_id, service_account_id, challenge_id, status, created_at, expires_at"
+
+// EnsureSchema создаёт таблицу при старте сервиса
+func EnsureSchema() error {
+	if usfl.DB == nil {
+		return errors.New("нет подключения к БД")
+	}
+	if _, err := usfl.DB.Exec(schema); err != nil {
+		return fmt.Errorf("не удалось создать таблицу pending_transactions: %w", err)
+	}
+	return nil
+}
+
+// Hold сохраняет перевод в статусе PENDING_CONFIRMATION
+func Hold(ctx context.Context, t *models.PendingTransaction) error {
+	t.Status = models.TransactionPendingConfirmation
+	return insert(ctx, t)
+}
+
+// Queue сохраняет перевод, отправляемый в очередь без подтверждения, в статусе QUEUED
+func Queue(ctx context.Context, t *models.PendingTransaction) error {
+	t.Status = models.TransactionQueued
+	t.ExpiresAt = t.CreatedAt
+	return insert(ctx, t)
+}
+
+// QueueFunds сохраняет пополнение или списание в статусе QUEUED и возвращает
+// сохранённую строку. Если у того же сервисного аккаунта операция с тем же видом и
+// внешним идентификатором уже есть, возвращается она, а t не сохраняется.
+func QueueFunds(ctx context.Context, t *models.PendingTransaction) (*models.PendingTransaction, error) {
+	t.Status = models.TransactionQueued
+	t.ExpiresAt = t.CreatedAt
+	res, err := usfl.DB.ExecContext(ctx, "INSERT INTO pending_transactions ("+columns+") VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)"+
+		" ON CONFLICT (service_account_id, kind, external_ref) WHERE external_ref <> '' DO NOTHING", values(t)...)
+	if err != nil {
+		return nil, err
+	}
+	if n, err := res.RowsAffected(); err != nil || n == 1 {
+		return t, err
+	}
+	return GetExternal(ctx, t.ServiceAccountID, t.Kind, t.ExternalRef)
+}
+
+// GetExternal возвращает пополнение или списание kind сервисного аккаунта по внешнему идентификатору
+func GetExternal(ctx context.Context, serviceAccountID int32, kind, externalRef string) (*models.PendingTransaction, error) {
+	return scan(usfl.DB.QueryRowContext(ctx, "SELECT "+columns+" FROM pending_transactions WHERE service_account_id = $1 AND kind = $2 AND external_ref = $3",
+		serviceAccountID, kind, externalRef))
+}
+
+func insert(ctx context.Context, t *models.PendingTransaction) error {
+	_, err := usfl.DB.ExecContext(ctx, "INSERT INTO pending_transactions ("+columns+") VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)", values(t)...)
+	return err
+}
+
+func values(t *models.PendingTransaction) []any {
+	if t.Kind == "" {
+		t.Kind = models.OperationTransfer
+	}
+	return []any{t.ID, t.Kind, t.ExternalRef, t.CardNumber, t.Amount, t.RecipientCardNumber, t.Currency, t.Convert, t.QuoteID,
+		t.UserID, t.ServiceAccountID, t.ChallengeID, t.Status, t.CreatedAt, t.ExpiresAt}
+}
+
+// Processed отмечает, что обработчик очереди закончил с переводом, проведён он или отклонён
+func Processed(ctx context.Context, id string) error {
+	_, err := usfl.DB.ExecContext(ctx, "UPDATE pending_transactions SET status = $2 WHERE id = $1 AND status IN ($3, $4)",
+		id, models.TransactionProcessed, models.TransactionQueued, models.TransactionConfirmed)
+	return err
+}
+
+// Reject отмечает пополнение или списание, которое обработчик очереди не смог провести
+func Reject(ctx context.Context, id string) error {
+	_, err := usfl.DB.ExecContext(ctx, "UPDATE pending_transactions SET status = $2 WHERE id = $1 AND status = $3",
+		id, models.TransactionRejected, models.TransactionQueued)
+	return err
+}
+
+// Requeue возвращает в QUEUED операцию, которую не удалось отправить в очередь.
+// Возвращает ErrNotFound, если её уже вернул параллельный запрос.
+func Requeue(ctx context.Context, id string) error {
+	res, err := usfl.DB.ExecContext(ctx, "UPDATE pending_transactions SET status = $2 WHERE id = $1 AND status = $3",
+		id, models.TransactionQueued, models.TransactionFailed)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// InFlight считает незавершённые переводы с карты и на карту. Перевод сохраняется
+// до отправки в очередь, поэтому проверка в транзакции, заблокировавшей строку карты,
+// видит все переводы, принятые до блокировки.
+func InFlight(ctx context.Context, q Querier, cardNumber string) (int, error) {
+	var n int
+	err := q.QueryRowContext(ctx, `SELECT count(*) FROM pending_transactions
+		WHERE (card_number = $1 OR recipient_card_number = $1)
+		AND (status IN ($2, $3) OR (status = $4 AND expires_at > now()))`,
+		cardNumber, models.TransactionQueued, models.TransactionConfirmed, models.TransactionPendingConfirmation).Scan(&n)
+	return n, err
+}
+
+// Get возвращает перевод в любом статусе
+func Get(ctx context.Context, id string) (*models.PendingTransaction, error) {
+	return scan(usfl.DB.QueryRowContext(ctx, "SELECT "+columns+" FROM pending_transactions WHERE id = $1", id))
+}
+
+func scan(row *sql.Row) (*models.PendingTransaction, error) {
+	var t models.PendingTransaction
+	err := row.Scan(&t.ID, &t.Kind, &t.ExternalRef, &t.CardNumber, &t.Amount, &t.RecipientCardNumber, &t.Currency, &t.Convert, &t.QuoteID,
+		&t.UserID, &t.ServiceAccountID, &t.ChallengeID, &t.Status, &t.CreatedAt, &t.ExpiresAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// Confirm атомарно переводит неистёкший перевод в CONFIRMED.
+// Возвращает ErrNotFound, если его уже подтвердили или он истёк.
+func Confirm(ctx context.Context, id string) error {
+	res, err := usfl.DB.ExecContext(ctx, "UPDATE pending_transactions SET status = $2 WHERE id = $1 AND status = $3 AND expires_at > now()",
+		id, models.TransactionConfirmed, models.TransactionPendingConfirmation)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Expire переводит в EXPIRED перевод, который уже нельзя подтвердить: попытки ввода
+// кода исчерпаны. Перевод в другом статусе не меняется.
+func Expire(ctx context.Context, id string) error {
+	_, err := usfl.DB.ExecContext(ctx, "UPDATE pending_transactions SET status = $2 WHERE id = $1 AND status = $3",
+		id, models.TransactionExpired, models.TransactionPendingConfirmation)
+	return err
+}
+
+// Fail отмечает подтверждённый перевод, который не удалось отправить в очередь
+func Fail(ctx context.Context, id string) error {
+	_, err := usfl.DB.ExecContext(ctx, "UPDATE pending_transactions SET status = $2 WHERE id = $1", id, models.TransactionFailed)
+	return err
+}
+
+// ExpireStale переводит просроченные переводы в EXPIRED
+func ExpireStale(ctx context.Context) (int64, error) {
+	res, err := usfl.DB.ExecContext(ctx, "UPDATE pending_transactions SET status = $1 WHERE status = $2 AND expires_at <= now()",
+		models.TransactionExpired, models.TransactionPendingConfirmation)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// RunExpiry периодически гасит неподтверждённые переводы
+func RunExpiry(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		n, err := ExpireStale(context.Background())
+		if err != nil {
+			log.Printf("Ошибка при истечении неподтверждённых переводов: %v", err)
+			continue
+		}
+		if n > 0 {
+			log.Printf("Истекло неподтверждённых переводов: %d", n)
+		}
+	}
+}
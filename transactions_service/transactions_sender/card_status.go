@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	usfl "fin-trans/database_methods_package"
+	models "fin-trans/models_package"
+	pb "fin-trans/proto/proto_generated/transactions_sender"
+	"fin-trans/transactions_service/limits"
+)
+
+// inactiveCardMessage - почему с карты в статусе status и сроком month/year нельзя
+// отправить перевод, или пустая строка для активной карты. Запись кэша без статуса
+// или срока пропускается: карту ещё раз проверит RefreshBalances при списании.
+// Срок проверяется отдельно от статуса: задача, переводящая карты в EXPIRED,
+// могла ещё не дойти до карты.
+func inactiveCardMessage(status string, month, year int32) string {
+	switch status {
+	case models.CardStatusActive, "":
+		if month != 0 && models.CardExpired(int(month), int(year), time.Now()) {
+			return "Срок действия карты истёк"
+		}
+		return ""
+	case models.CardStatusFrozen:
+		return "Карта заморожена, разморозьте её, чтобы отправить перевод"
+	case models.CardStatusBlocked:
+		return "Карта заблокирована банком, обратитесь в поддержку"
+	case models.CardStatusExpired:
+		return "Срок действия карты истёк"
+	case models.CardStatusClosed:
+		return "Карта закрыта"
+	default:
+		return "Карта недоступна для переводов"
+	}
+}
+
+// limitMessage - быстрая проверка лимитов карты при создании перевода: пустая строка,
+// если перевод в них укладывается. Окончательно лимиты проверяет RefreshBalances
+// при списании, поэтому ошибка БД здесь перевод не останавливает.
+func limitMessage(ctx context.Context, cardNumber string, amount float64) string {
+	if usfl.DB == nil {
+		return ""
+	}
+	err := limits.Check(ctx, usfl.DB, cardNumber, amount, time.Now())
+	var exceeded *limits.ExceededError
+	switch {
+	case err == nil:
+		return ""
+	case errors.As(err, &exceeded):
+		return exceeded.Error()
+	default:
+		log.Printf("Не удалось проверить лимиты карты %s: %v", cardNumber, err)
+		return ""
+	}
+}
+
+// currencyMessage сверяет валюту перевода с валютой карты отправителя: сумма
+// указывается в валюте карты. Если клиент валюту не указал, в req записывается
+// валюта карты. Запись кэша без валюты пропускается, её проверит RefreshBalances.
+func currencyMessage(req *pb.CreateTransactionRequest, cardCurrency string) string {
+	switch {
+	case cardCurrency == "":
+		return ""
+	case req.Currency == "":
+		req.Currency = cardCurrency
+		return ""
+	case req.Currency != cardCurrency:
+		return fmt.Sprintf("Сумма перевода указывается в валюте карты отправителя (%s)", cardCurrency)
+	}
+	return ""
+}
+
+// conversionMessage - ответ на перевод из валюты from на карту в валюте to без запроса конвертации
+func conversionMessage(from, to string) string {
+	return fmt.Sprintf("Карта получателя в валюте %s, а не %s: переведите с конвертацией (convert или quote_id) или выберите другую карту", to, from)
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	models "fin-trans/models_package"
+)
+
+// defaultConfirmationThresholds - пороги подтверждения по умолчанию в валюте перевода
+var defaultConfirmationThresholds = map[string]float64{
+	models.CurrencyRUB: 10000,
+	models.CurrencyUSD: 100,
+	models.CurrencyEUR: 100,
+}
+
+// confirmationConfig - переводы дороже порога в своей валюте ждут подтверждения одноразовым кодом
+type confirmationConfig struct {
+	Thresholds map[string]float64 // по валютам; 0 - подтверждение не требуется
+	TTL        time.Duration      // сколько перевод ждёт подтверждения
+}
+
+// loadConfirmationConfig читает пороги из TRANSACTIONS_CONFIRMATION_THRESHOLD_<валюта>.
+// Рублёвый порог по-прежнему можно задать TRANSACTIONS_CONFIRMATION_THRESHOLD.
+func loadConfirmationConfig() confirmationConfig {
+	c := confirmationConfig{
+		Thresholds: make(map[string]float64, len(models.Currencies)),
+		TTL:        envDuration("TRANSACTIONS_CONFIRMATION_TTL", 5*time.Minute),
+	}
+	for _, currency := range models.Currencies {
+		def := defaultConfirmationThresholds[currency]
+		if currency == models.CurrencyRUB {
+			def = envFloat("TRANSACTIONS_CONFIRMATION_THRESHOLD", def)
+		}
+		c.Thresholds[currency] = envFloat("TRANSACTIONS_CONFIRMATION_THRESHOLD_"+currency, def)
+	}
+	return c
+}
+
+// required - нужно ли подтверждать перевод на сумму amount в валюте currency. Валюта
+// неизвестна, если сервис карт был недоступен: тогда берётся наименьший из порогов.
+func (c confirmationConfig) required(amount float64, currency string) bool {
+	threshold, ok := c.Thresholds[currency]
+	if !ok {
+		for _, t := range c.Thresholds {
+			if t > 0 && (!ok || t < threshold) {
+				threshold, ok = t, true
+			}
+		}
+	}
+	return threshold > 0 && amount > threshold
+}
+
+// fxConfig - на сколько фиксируется курс котировки
+type fxConfig struct {
+	QuoteTTL time.Duration
+}
+
+func loadFXConfig() fxConfig {
+	return fxConfig{QuoteTTL: envDuration("FX_QUOTE_TTL", time.Minute)}
+}
+
+// envFloat читает неотрицательное число из переменной окружения
+func envFloat(name string, def float64) float64 {
+	value := os.Getenv(name)
+	if value == "" {
+		return def
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil || f < 0 {
+		log.Printf("Неверное значение %s=%q, используется %v", name, value, def)
+		return def
+	}
+	return f
+}
+
+// envDuration читает длительность вида "15m" из переменной окружения
+func envDuration(name string, def time.Duration) time.Duration {
+	value := os.Getenv(name)
+	if value == "" {
+		return def
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		log.Printf("Неверное значение %s=%q, используется %v", name, value, def)
+		return def
+	}
+	return d
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	authint "fin-trans/auth_interceptor_package"
+	authpb "fin-trans/auth_service/proto"
+	models "fin-trans/models_package"
+	cardpb "fin-trans/proto/proto_generated/cards_service"
+	pb "fin-trans/proto/proto_generated/transactions_sender"
+	"fin-trans/transactions_service/fx"
+	"fin-trans/transactions_service/pending"
+)
+
+// startTransaction проверяет получателя, отправляет перевод в очередь и возвращает resp.
+// Перевод дороже порога вместо этого ждёт кода подтверждения. Сервисные аккаунты
+// код получить не могут, их переводы ограничиваются scopes ключа.
+func (s *server) startTransaction(ctx context.Context, caller *authint.Identity, req *pb.CreateTransactionRequest, resp *pb.CreateTransactionResponse) (*pb.CreateTransactionResponse, error) {
+	// Лимит проверок получателя считается по пользователю, а не по сервису переводов
+	recipient, err := s.cardClient.CheckRecipientCard(ctx, &cardpb.CheckRecipientCardRequest{
+		RecipientCardNumber: req.RecipientCardNumber,
+		OnBehalfOfUserId:    caller.UserID,
+	})
+	switch {
+	case status.Code(err) == codes.ResourceExhausted:
+		return nil, err
+	case err != nil:
+		// Сервис карт недоступен: получателя проверит RefreshBalances при списании
+		log.Printf("Ошибка при проверке карты получателя: %v", err)
+	case !recipient.Availability:
+		return &pb.CreateTransactionResponse{
+			IsCreated: false,
+			Message:   "Карта получателя не найдена или недоступна",
+		}, nil
+	}
+
+	// Перевод на карту в другой валюте проходит по курсу котировки. Если сервис карт
+	// недоступен, валюты сверит RefreshBalances при списании.
+	var quote *models.FxQuote
+	if err == nil && req.Currency != "" && recipient.Currency != "" && recipient.Currency != req.Currency {
+		if !req.Convert && req.QuoteId == "" {
+			return &pb.CreateTransactionResponse{
+				IsCreated: false,
+				Message:   conversionMessage(req.Currency, recipient.Currency),
+			}, nil
+		}
+		var message string
+		quote, message, err = s.conversionQuote(ctx, caller, req, recipient.Currency)
+		if err != nil {
+			return nil, err
+		}
+		if message != "" {
+			return &pb.CreateTransactionResponse{
+				IsCreated: false,
+				Message:   message,
+			}, nil
+		}
+		req.QuoteId = quote.ID
+	}
+
+	if !caller.IsServiceAccount() && s.confirm.required(req.Amount, req.Currency) {
+		resp, err = s.holdTransaction(ctx, caller, req)
+		if err != nil {
+			return nil, err
+		}
+	} else if err := s.SendTransactionToQueue(ctx, req); err != nil {
+		log.Printf("Ошибка при отправке перевода в очередь: %v", err)
+		return nil, status.Error(codes.Unavailable, "could not enqueue transaction, please try again")
+	}
+	resp.RecipientName = recipient.GetRecipientUsername()
+	if quote != nil {
+		resp.Rate = quote.Rate
+		resp.TargetAmount = fx.Convert(req.Amount, quote.Rate)
+		resp.TargetCurrency = quote.ToCurrency
+	}
+	return resp, nil
+}
+
+// holdTransaction сохраняет перевод в PENDING_CONFIRMATION и просит AuthService отправить код
+func (s *server) holdTransaction(ctx context.Context, caller *authint.Identity, req *pb.CreateTransactionRequest) (*pb.CreateTransactionResponse, error) {
+	id, err := newTransactionID()
+	if err != nil {
+		return nil, status.Error(codes.Internal, "could not create transaction")
+	}
+
+	challenge, err := s.authClient.IssueStepUpCode(ctx, &authpb.IssueStepUpCodeRequest{
+		UserId:      caller.UserID,
+		Description: fmt.Sprintf("transfer of %.2f from card %s to card %s", req.Amount, maskCardNumber(req.CardNumber), maskCardNumber(req.RecipientCardNumber)),
+	})
+	if err != nil {
+		log.Printf("Ошибка при запросе кода подтверждения перевода: %v", err)
+		return nil, status.Error(codes.Unavailable, "could not send confirmation code")
+	}
+
+	now := time.Now()
+	transaction := &models.PendingTransaction{
+		ID:                  id,
+		CardNumber:          req.CardNumber,
+		Amount:              req.Amount,
+		RecipientCardNumber: req.RecipientCardNumber,
+		Currency:            req.Currency,
+		Convert:             req.Convert,
+		QuoteID:             req.QuoteId,
+		UserID:              caller.UserID,
+		ChallengeID:         challenge.ChallengeId,
+		CreatedAt:           now,
+		ExpiresAt:           now.Add(s.confirm.TTL),
+	}
+	if err := pending.Hold(ctx, transaction); err != nil {
+		log.Printf("Ошибка при сохранении перевода, ожидающего подтверждения: %v", err)
+		return nil, status.Error(codes.Internal, "could not create transaction")
+	}
+
+	return &pb.CreateTransactionResponse{
+		IsCreated:     false,
+		Message:       "Подтвердите перевод кодом, который мы вам отправили",
+		TransactionId: transaction.ID,
+		Status:        transaction.Status,
+	}, nil
+}
+
+// ConfirmTransaction проверяет код в AuthService и отправляет отложенный перевод в очередь
+func (s *server) ConfirmTransaction(ctx context.Context, req *pb.ConfirmTransactionRequest) (*pb.ConfirmTransactionResponse, error) {
+	caller, err := authint.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Чужой перевод выглядит так же, как несуществующий
+	transaction, err := pending.Get(ctx, req.TransactionId)
+	if errors.Is(err, pending.ErrNotFound) || (err == nil && transaction.UserID != caller.UserID) {
+		return nil, status.Error(codes.NotFound, "transaction not found")
+	}
+	if err != nil {
+		log.Printf("Ошибка при получении перевода %s: %v", req.TransactionId, err)
+		return nil, status.Error(codes.Internal, "could not load transaction")
+	}
+	if transaction.Status != models.TransactionPendingConfirmation {
+		return &pb.ConfirmTransactionResponse{Success: false, Message: "Перевод уже обработан", Status: transaction.Status}, nil
+	}
+	if time.Now().After(transaction.ExpiresAt) {
+		return &pb.ConfirmTransactionResponse{Success: false, Message: "Время на подтверждение перевода истекло", Status: models.TransactionExpired}, nil
+	}
+
+	verified, err := s.authClient.VerifyStepUpCode(ctx, &authpb.VerifyStepUpCodeRequest{
+		ChallengeId: transaction.ChallengeID,
+		UserId:      transaction.UserID,
+		Code:        req.Code,
+	})
+	if err != nil {
+		log.Printf("Ошибка при проверке кода подтверждения: %v", err)
+		return nil, status.Error(codes.Unavailable, "could not verify confirmation code")
+	}
+	if !verified.Valid && verified.AttemptsExhausted {
+		// Код больше не проверить: перевод гасится сразу, а не по истечении TTL,
+		// иначе до тех пор он мешал бы закрыть карту
+		if err := pending.Expire(ctx, transaction.ID); err != nil {
+			log.Printf("Ошибка при обновлении статуса перевода %s: %v", transaction.ID, err)
+		}
+		return &pb.ConfirmTransactionResponse{Success: false, Message: "Попытки ввода кода исчерпаны, создайте перевод заново", Status: models.TransactionExpired}, nil
+	}
+	if !verified.Valid {
+		return &pb.ConfirmTransactionResponse{Success: false, Message: "Неверный или просроченный код", Status: transaction.Status}, nil
+	}
+
+	// Два одновременных подтверждения: в очередь уходит только первое
+	if err := pending.Confirm(ctx, transaction.ID); err != nil {
+		if errors.Is(err, pending.ErrNotFound) {
+			return &pb.ConfirmTransactionResponse{Success: false, Message: "Перевод уже обработан или истёк"}, nil
+		}
+		log.Printf("Ошибка при подтверждении перевода %s: %v", transaction.ID, err)
+		return nil, status.Error(codes.Internal, "could not confirm transaction")
+	}
+
+	err = s.publishTransaction(models.TransactionMessage{
+		ID:                  transaction.ID,
+		CardNumber:          transaction.CardNumber,
+		Amount:              transaction.Amount,
+		RecipientCardNumber: transaction.RecipientCardNumber,
+		Currency:            transaction.Currency,
+		Convert:             transaction.Convert,
+		QuoteID:             transaction.QuoteID,
+		UserID:              transaction.UserID,
+	})
+	if err != nil {
+		log.Printf("Ошибка при отправке подтверждённого перевода %s в очередь: %v", transaction.ID, err)
+		if err := pending.Fail(ctx, transaction.ID); err != nil {
+			log.Printf("Ошибка при обновлении статуса перевода %s: %v", transaction.ID, err)
+		}
+		return nil, status.Error(codes.Unavailable, "could not enqueue transaction, please create it again")
+	}
+
+	return &pb.ConfirmTransactionResponse{
+		Success: true,
+		Message: "Перевод подтверждён, вы получите уведомление, когда транзакция завершится",
+		Status:  models.TransactionConfirmed,
+	}, nil
+}
+
+// newTransactionID - идентификатор строки в pending_transactions
+func newTransactionID() (string, error) {
+	idBytes := make([]byte, 16)
+	if _, err := rand.Read(idBytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(idBytes), nil
+}
+
+// maskCardNumber оставляет последние четыре цифры номера карты
+func maskCardNumber(number string) string {
+	if len(number) <= 4 {
+		return number
+	}
+	return "*" + number[len(number)-4:]
+}
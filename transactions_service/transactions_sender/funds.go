@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	authint "fin-trans/auth_interceptor_package"
+	cardnumber "fin-trans/card_number_package"
+	models "fin-trans/models_package"
+	cardpb "fin-trans/proto/proto_generated/cards_service"
+	pb "fin-trans/proto/proto_generated/transactions_sender"
+	"fin-trans/transactions_service/pending"
+)
+
+// maxExternalRefLength ограничивает идентификатор операции во внешней системе
+const maxExternalRefLength = 128
+
+// Deposit зачисляет на карту деньги из внешней системы
+func (s *server) Deposit(ctx context.Context, req *pb.DepositRequest) (*pb.FundsResponse, error) {
+	return s.fundCard(ctx, models.OperationDeposit, req.CardNumber, req.Amount, req.Currency, req.ExternalReferenceId)
+}
+
+// Withdraw списывает с карты деньги во внешнюю систему
+func (s *server) Withdraw(ctx context.Context, req *pb.WithdrawRequest) (*pb.FundsResponse, error) {
+	return s.fundCard(ctx, models.OperationWithdrawal, req.CardNumber, req.Amount, req.Currency, req.ExternalReferenceId)
+}
+
+// fundCard отправляет пополнение или списание в очередь переводов: баланс меняет
+// обработчик очереди и записывает операцию в историю, как перевод. Повторный запрос
+// того же сервисного аккаунта с тем же external_reference_id возвращает уже принятую
+// операцию, а не проводит её снова. Идентификаторы разных аккаунтов не пересекаются.
+func (s *server) fundCard(ctx context.Context, kind, number string, amount float64, currency, externalRef string) (*pb.FundsResponse, error) {
+	caller, err := authint.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if cardnumber.ValidateFormat(number) != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid card number")
+	}
+	if amount <= 0 || math.IsInf(amount, 0) || math.IsNaN(amount) {
+		return nil, status.Error(codes.InvalidArgument, "amount must be positive")
+	}
+	if currency != "" && !models.ValidCurrency(currency) {
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported currency, expected one of %v", models.Currencies)
+	}
+	if externalRef == "" || len(externalRef) > maxExternalRefLength {
+		return nil, status.Errorf(codes.InvalidArgument, "external reference id is required and must be at most %d characters", maxExternalRefLength)
+	}
+
+	operation := &models.PendingTransaction{
+		Kind:             kind,
+		ExternalRef:      externalRef,
+		Amount:           amount,
+		Currency:         currency,
+		UserID:           caller.UserID,
+		ServiceAccountID: caller.ServiceAccountID,
+	}
+	if kind == models.OperationDeposit {
+		operation.RecipientCardNumber = number
+	} else {
+		operation.CardNumber = number
+	}
+
+	existing, err := pending.GetExternal(ctx, caller.ServiceAccountID, kind, externalRef)
+	if err == nil {
+		return s.repeatFunds(ctx, caller, existing, operation)
+	}
+	if !errors.Is(err, pending.ErrNotFound) {
+		log.Printf("Ошибка при поиске операции %s %s: %v", kind, externalRef, err)
+		return nil, status.Error(codes.Internal, "could not load operation")
+	}
+
+	if message := s.fundsMessage(ctx, operation); message != "" {
+		return &pb.FundsResponse{Accepted: false, Message: message}, nil
+	}
+
+	operation.ID, err = newTransactionID()
+	if err != nil {
+		return nil, status.Error(codes.Internal, "could not create operation")
+	}
+	operation.CreatedAt = time.Now()
+	// Параллельный запрос с тем же идентификатором мог успеть сохранить операцию первым
+	stored, err := pending.QueueFunds(ctx, operation)
+	if err != nil {
+		log.Printf("Ошибка при сохранении операции %s %s: %v", kind, externalRef, err)
+		return nil, status.Error(codes.Internal, "could not create operation")
+	}
+	if stored.ID != operation.ID {
+		return s.repeatFunds(ctx, caller, stored, operation)
+	}
+
+	if err := s.publishFunds(caller, stored); err != nil {
+		return nil, err
+	}
+	log.Printf("Операция %s %s по карте %s на сумму %.2f принята пользователем %s", kind, externalRef, number, amount, caller.Username)
+	return &pb.FundsResponse{
+		Accepted:      true,
+		Message:       "Операция принята и будет проведена в порядке очереди",
+		TransactionId: stored.ID,
+		Status:        stored.Status,
+	}, nil
+}
+
+// fundsMessage - быстрая проверка карты перед отправкой операции в очередь: пустая
+// строка, если операцию можно принять. Окончательно карту, баланс и лимиты проверяет
+// обработчик очереди, поэтому недоступность сервиса карт операцию не останавливает.
+// Валюта операции без валюты заполняется валютой карты.
+func (s *server) fundsMessage(ctx context.Context, operation *models.PendingTransaction) string {
+	number := operation.CardNumber + operation.RecipientCardNumber
+	cardRes, err := s.cardClient.GetCard(ctx, &cardpb.GetCardRequest{CardNumber: number})
+	if err != nil {
+		log.Printf("Ошибка при получении карты %s: %v", number, err)
+		return ""
+	}
+	if cardRes.CardNumber == "" {
+		return "Карта не найдена"
+	}
+	if message := inactiveCardMessage(cardRes.Status, cardRes.ExpiryMonth, cardRes.ExpiryYear); message != "" {
+		return message
+	}
+	switch {
+	case cardRes.Currency == "":
+	case operation.Currency == "":
+		operation.Currency = cardRes.Currency
+	case operation.Currency != cardRes.Currency:
+		return fmt.Sprintf("Сумма операции указывается в валюте карты (%s)", cardRes.Currency)
+	}
+	if operation.Kind == models.OperationWithdrawal {
+		if cardRes.Balance < operation.Amount {
+			return "Недостаточно средств на карте"
+		}
+		return limitMessage(ctx, number, operation.Amount)
+	}
+	return ""
+}
+
+// repeatFunds отвечает на повторный запрос операции existing. Операцию, которую не
+// удалось отправить в очередь, повторный запрос отправляет ещё раз.
+func (s *server) repeatFunds(ctx context.Context, caller *authint.Identity, existing, requested *models.PendingTransaction) (*pb.FundsResponse, error) {
+	if existing.CardNumber != requested.CardNumber || existing.RecipientCardNumber != requested.RecipientCardNumber ||
+		existing.Amount != requested.Amount || (requested.Currency != "" && existing.Currency != "" && existing.Currency != requested.Currency) {
+		return nil, status.Error(codes.FailedPrecondition, "external reference id was already used for a different operation")
+	}
+
+	if existing.Status == models.TransactionFailed {
+		err := pending.Requeue(ctx, existing.ID)
+		switch {
+		case err == nil:
+			existing.Status = models.TransactionQueued
+			if err := s.publishFunds(caller, existing); err != nil {
+				return nil, err
+			}
+		case errors.Is(err, pending.ErrNotFound):
+			// Операцию уже отправил параллельный повтор
+			existing.Status = models.TransactionQueued
+		default:
+			log.Printf("Ошибка при повторной отправке операции %s: %v", existing.ID, err)
+			return nil, status.Error(codes.Internal, "could not enqueue operation")
+		}
+	}
+
+	resp := &pb.FundsResponse{
+		Accepted:      existing.Status != models.TransactionRejected,
+		TransactionId: existing.ID,
+		Status:        existing.Status,
+		Duplicate:     true,
+	}
+	switch existing.Status {
+	case models.TransactionProcessed:
+		resp.Message = "Операция уже проведена"
+	case models.TransactionRejected:
+		resp.Message = "Операция отклонена"
+	default:
+		resp.Message = "Операция уже принята и будет проведена в порядке очереди"
+	}
+	return resp, nil
+}
+
+// publishFunds отправляет операцию в очередь. Если очередь недоступна, операция
+// остаётся в FAILED и уйдёт в очередь при повторе с тем же идентификатором.
+func (s *server) publishFunds(caller *authint.Identity, operation *models.PendingTransaction) error {
+	err := s.publishTransaction(models.TransactionMessage{
+		ID:                  operation.ID,
+		Kind:                operation.Kind,
+		ExternalRef:         operation.ExternalRef,
+		CardNumber:          operation.CardNumber,
+		Amount:              operation.Amount,
+		RecipientCardNumber: operation.RecipientCardNumber,
+		Currency:            operation.Currency,
+		UserID:              caller.UserID,
+		ServiceAccountID:    operation.ServiceAccountID,
+	})
+	if err == nil {
+		return nil
+	}
+	log.Printf("Ошибка при отправке операции %s в очередь: %v", operation.ID, err)
+	if err := pending.Fail(context.Background(), operation.ID); err != nil {
+		log.Printf("Ошибка при обновлении статуса операции %s: %v", operation.ID, err)
+	}
+	return status.Error(codes.Unavailable, "could not enqueue operation, retry with the same external reference id")
+}
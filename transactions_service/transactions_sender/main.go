@@ -5,21 +5,33 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"math"
 	"net"
 	"net/http"
+	"os"
 	"time"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/streadway/amqp"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 
+	authint "fin-trans/auth_interceptor_package"
+	authpb "fin-trans/auth_service/proto"
+	cardnumber "fin-trans/card_number_package"
 	usfl "fin-trans/database_methods_package"
+	models "fin-trans/models_package"
 	cardpb "fin-trans/proto/proto_generated/cards_service" // Путь к сгенерированным protobuf-файлам сервиса карт
 	rds "fin-trans/proto/proto_generated/redis_cache_service"
 	pb "fin-trans/proto/proto_generated/transactions_sender" // Путь к сгенерированным protobuf-файлам сервиса транзакций (этого сервиса)
+	"fin-trans/transactions_service/fx"
+	"fin-trans/transactions_service/history"
+	"fin-trans/transactions_service/limits"
+	"fin-trans/transactions_service/pending"
 	trhr "fin-trans/transactions_service/transactions_handler"
 )
 
@@ -27,53 +39,134 @@ var (
 	QueueName = "TransactionsQueue"
 )
 
+// transactionsPolicy - кто может вызывать методы TransactionService.
+// Переводы делают клиенты со своих карт, историю любой карты видят операторы.
+// Сервисным аккаунтам методы открывают scopes их API-ключей. Пополняют и списывают
+// деньги только внешние системы со scope funds:write.
+var transactionsPolicy = authint.Policy{
+	pb.TransactionService_CreateTransaction_FullMethodName:  authint.Allow(models.RoleCustomer).OrScope(authint.ScopeTransactionsCreate),
+	pb.TransactionService_ListTransactions_FullMethodName:   authint.Allow(models.RoleCustomer).OrScope(authint.ScopeTransactionsRead),
+	pb.TransactionService_ConfirmTransaction_FullMethodName: authint.Allow(models.RoleCustomer),
+	pb.TransactionService_CreateQuote_FullMethodName:        authint.Allow(models.RoleCustomer).OrScope(authint.ScopeTransactionsCreate),
+	pb.TransactionService_GetQuote_FullMethodName:           authint.Allow(models.RoleCustomer).OrScope(authint.ScopeTransactionsCreate),
+	pb.TransactionService_Deposit_FullMethodName:            authint.ScopeOnly(authint.ScopeFundsWrite),
+	pb.TransactionService_Withdraw_FullMethodName:           authint.ScopeOnly(authint.ScopeFundsWrite),
+}.Merge(authint.ReflectionPolicy)
+
 type server struct {
 	pb.UnimplementedTransactionServiceServer
 	cardClient  cardpb.CardServiceClient
 	rabbitConn  *amqp.Connection
 	redisServer *redis.Client
 	redisClient rds.CardServiceClient
+	authClient  authpb.AuthServiceClient // одноразовые коды подтверждения крупных переводов
+	confirm     confirmationConfig
+	rates       fx.RateProvider // курсы для котировок переводов между валютами
+	fx          fxConfig
 }
 
-func (s *server) SendTransactionToQueue(ctx context.Context, req *pb.CreateTransactionRequest) {
+// SendTransactionToQueue сохраняет перевод в pending_transactions и отправляет его в очередь.
+// Строка сохраняется до ответа клиенту: пока перевод не проведён, карты отправителя
+// и получателя нельзя закрыть, поэтому перевод не должен оказаться в очереди без неё.
+func (s *server) SendTransactionToQueue(ctx context.Context, req *pb.CreateTransactionRequest) error {
+	// Владелец карты проверяется ещё раз при списании, если сервис карт сейчас недоступен
+	caller, _ := authint.FromContext(ctx)
+
+	id, err := newTransactionID()
+	if err != nil {
+		return fmt.Errorf("ошибка при создании идентификатора перевода: %w", err)
+	}
+	queued := &models.PendingTransaction{
+		ID:                  id,
+		CardNumber:          req.CardNumber,
+		Amount:              req.Amount,
+		RecipientCardNumber: req.RecipientCardNumber,
+		Currency:            req.Currency,
+		Convert:             req.Convert,
+		QuoteID:             req.QuoteId,
+		UserID:              caller.UserID,
+		CreatedAt:           time.Now(),
+	}
+	if err := pending.Queue(ctx, queued); err != nil {
+		return fmt.Errorf("ошибка при сохранении перевода %s перед отправкой в очередь: %w", id, err)
+	}
+
+	transaction := models.TransactionMessage{
+		ID:                  id,
+		CardNumber:          req.CardNumber,
+		Amount:              req.Amount,
+		RecipientCardNumber: req.RecipientCardNumber,
+		Currency:            req.Currency,
+		Convert:             req.Convert,
+		QuoteID:             req.QuoteId,
+		UserID:              caller.UserID,
+		ServiceAccountID:    caller.ServiceAccountID,
+	}
 	//Вынесено в отдельную горутину для асинхронного выполнения
 	go func() {
-		transaction := map[string]interface{}{
-			"card_number":           req.CardNumber,
-			"amount":                req.Amount,
-			"recipient_card_number": req.RecipientCardNumber,
+		if err := s.publishTransaction(transaction); err != nil {
+			log.Printf("Ошибка при отправке транзакции в обменник сообщений: %v", err)
+			// Неотправленный перевод не должен мешать закрыть карту
+			if err := pending.Fail(context.Background(), id); err != nil {
+				log.Printf("Ошибка при обновлении статуса перевода %s: %v", id, err)
+			}
 		}
+	}()
+	return nil
+}
 
-		// Открываем канал для сообщений RabbitMQ
-		channel, err := s.rabbitConn.Channel()
-		if err != nil {
-			log.Printf("Ошибка при открытии канала для сообщений: %v", err)
-			return
-		}
-		defer channel.Close()
+// publishTransaction кладёт перевод в очередь RabbitMQ
+func (s *server) publishTransaction(transaction models.TransactionMessage) error {
+	// Открываем канал для сообщений RabbitMQ
+	channel, err := s.rabbitConn.Channel()
+	if err != nil {
+		return fmt.Errorf("ошибка при открытии канала для сообщений: %w", err)
+	}
+	defer channel.Close()
 
-		body, err := json.Marshal(transaction)
-		if err != nil {
-			log.Printf("Ошибка при json энкодинге транзакции: %v", err)
-		}
+	body, err := json.Marshal(transaction)
+	if err != nil {
+		return fmt.Errorf("ошибка при json энкодинге транзакции: %w", err)
+	}
 
-		err = channel.Publish(
-			"",        // Прямой обменник
-			QueueName, // Имя очереди
-			false,     // Признак сходимости
-			false,     // Признак приоритета
-			amqp.Publishing{
-				ContentType:  "application/json",
-				Body:         body,
-				DeliveryMode: amqp.Persistent, // сообщение будет устойчивым
-			})
-		if err != nil {
-			log.Printf("Ошибка при отправке транзакции в обменник сообщений: %v", err)
-		}
-	}()
+	return channel.Publish(
+		"",        // Прямой обменник
+		QueueName, // Имя очереди
+		false,     // Признак сходимости
+		false,     // Признак приоритета
+		amqp.Publishing{
+			ContentType:  "application/json",
+			Body:         body,
+			DeliveryMode: amqp.Persistent, // сообщение будет устойчивым
+		})
 }
 
 func (s *server) CreateTransaction(ctx context.Context, req *pb.CreateTransactionRequest) (*pb.CreateTransactionResponse, error) {
+	caller, err := authint.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if cardnumber.ValidateFormat(req.CardNumber) != nil {
+		return &pb.CreateTransactionResponse{
+			IsCreated: false,
+			Message:   "Неверный номер карты отправителя",
+		}, nil
+	}
+	if cardnumber.ValidateFormat(req.RecipientCardNumber) != nil {
+		return &pb.CreateTransactionResponse{
+			IsCreated: false,
+			Message:   "Неверный номер карты получателя",
+		}, nil
+	}
+	// Сумма проверяется до любой ветки, которая отправляет перевод в очередь
+	if req.Amount <= 0 || math.IsInf(req.Amount, 0) || math.IsNaN(req.Amount) {
+		return &pb.CreateTransactionResponse{
+			IsCreated: false,
+			Message:   "Введите корректное значение в поле Amount",
+		}, nil
+	}
+
 	if s.redisClient != nil {
 		//Здесь сначала бежим проверять наличие карты в репликации части основных данных в Redis
 		cardRes, err := s.redisClient.RedisGetCard(ctx, &rds.RedisGetCardRequest{CardNumber: req.CardNumber})
@@ -91,20 +184,26 @@ func (s *server) CreateTransaction(ctx context.Context, req *pb.CreateTransactio
 				log.Printf("Не найдена карта при создании транзакции: %v", err)
 
 				//Запуск горутины, отправляющей
-				go s.SendTransactionToQueue(ctx, req)
-				return &pb.CreateTransactionResponse{
+				return s.startTransaction(ctx, caller, req, &pb.CreateTransactionResponse{
 					IsCreated: false,
 					Message:   "Перевод успешно начат, вы получите уведомление, когда транзакция завершится",
-				}, nil
+				})
 			}
 
-			if cardRes.CardNumber == "" {
+			if cardRes.CardNumber == "" || !caller.CanAccessUser(cardRes.UserId) {
 				return &pb.CreateTransactionResponse{
 					IsCreated: false,
 					Message:   "У вас нет такой карты",
 				}, nil
 			}
 
+			if message := inactiveCardMessage(cardRes.Status, cardRes.ExpiryMonth, cardRes.ExpiryYear); message != "" {
+				return &pb.CreateTransactionResponse{
+					IsCreated: false,
+					Message:   message,
+				}, nil
+			}
+
 			if req.Amount <= 0.0 {
 				return &pb.CreateTransactionResponse{
 					IsCreated: false,
@@ -112,15 +211,27 @@ func (s *server) CreateTransaction(ctx context.Context, req *pb.CreateTransactio
 				}, nil
 			}
 
+			if message := currencyMessage(req, cardRes.Currency); message != "" {
+				return &pb.CreateTransactionResponse{
+					IsCreated: false,
+					Message:   message,
+				}, nil
+			}
+
+			if message := limitMessage(ctx, req.CardNumber, req.Amount); message != "" {
+				return &pb.CreateTransactionResponse{
+					IsCreated: false,
+					Message:   message,
+				}, nil
+			}
+
 			if cardRes.Balance >= req.Amount {
 
 				//Запуск горутины, отправляющей
-				go s.SendTransactionToQueue(ctx, req)
-
-				return &pb.CreateTransactionResponse{
+				return s.startTransaction(ctx, caller, req, &pb.CreateTransactionResponse{
 					IsCreated: true,
 					Message:   "Перевод успешно начат, вы получите уведомление, когда транзакция завершится",
-				}, nil
+				})
 			} else {
 				return &pb.CreateTransactionResponse{
 					IsCreated: false,
@@ -128,13 +239,20 @@ func (s *server) CreateTransaction(ctx context.Context, req *pb.CreateTransactio
 				}, nil
 			}
 		} else {
-			if cardRes.CardNumber == "" {
+			if cardRes.CardNumber == "" || !caller.CanAccessUser(cardRes.UserId) {
 				return &pb.CreateTransactionResponse{
 					IsCreated: false,
 					Message:   "У вас нет такой карты",
 				}, nil
 			}
 
+			if message := inactiveCardMessage(cardRes.Status, cardRes.ExpiryMonth, cardRes.ExpiryYear); message != "" {
+				return &pb.CreateTransactionResponse{
+					IsCreated: false,
+					Message:   message,
+				}, nil
+			}
+
 			if req.Amount <= 0.0 {
 				return &pb.CreateTransactionResponse{
 					IsCreated: false,
@@ -142,15 +260,27 @@ func (s *server) CreateTransaction(ctx context.Context, req *pb.CreateTransactio
 				}, nil
 			}
 
+			if message := currencyMessage(req, cardRes.Currency); message != "" {
+				return &pb.CreateTransactionResponse{
+					IsCreated: false,
+					Message:   message,
+				}, nil
+			}
+
+			if message := limitMessage(ctx, req.CardNumber, req.Amount); message != "" {
+				return &pb.CreateTransactionResponse{
+					IsCreated: false,
+					Message:   message,
+				}, nil
+			}
+
 			if cardRes.Balance >= req.Amount {
 
 				//Запуск горутины, отправляющей
-				go s.SendTransactionToQueue(ctx, req)
-
-				return &pb.CreateTransactionResponse{
+				return s.startTransaction(ctx, caller, req, &pb.CreateTransactionResponse{
 					IsCreated: true,
 					Message:   "Перевод успешно начат, вы получите уведомление, когда транзакция завершится",
-				}, nil
+				})
 			} else {
 				return &pb.CreateTransactionResponse{
 					IsCreated: false,
@@ -171,20 +301,26 @@ func (s *server) CreateTransaction(ctx context.Context, req *pb.CreateTransactio
 			log.Printf("Не найдена карта при создании транзакции: %v", err)
 
 			//Запуск горутины, отправляющей
-			go s.SendTransactionToQueue(ctx, req)
-			return &pb.CreateTransactionResponse{
+			return s.startTransaction(ctx, caller, req, &pb.CreateTransactionResponse{
 				IsCreated: false,
 				Message:   "Перевод успешно начат, вы получите уведомление, когда транзакция завершится",
-			}, nil
+			})
 		}
 
-		if cardRes.CardNumber == "" {
+		if cardRes.CardNumber == "" || !caller.CanAccessUser(cardRes.UserId) {
 			return &pb.CreateTransactionResponse{
 				IsCreated: false,
 				Message:   "У вас нет такой карты",
 			}, nil
 		}
 
+		if message := inactiveCardMessage(cardRes.Status, cardRes.ExpiryMonth, cardRes.ExpiryYear); message != "" {
+			return &pb.CreateTransactionResponse{
+				IsCreated: false,
+				Message:   message,
+			}, nil
+		}
+
 		if req.Amount <= 0.0 {
 			return &pb.CreateTransactionResponse{
 				IsCreated: false,
@@ -192,15 +328,27 @@ func (s *server) CreateTransaction(ctx context.Context, req *pb.CreateTransactio
 			}, nil
 		}
 
+		if message := currencyMessage(req, cardRes.Currency); message != "" {
+			return &pb.CreateTransactionResponse{
+				IsCreated: false,
+				Message:   message,
+			}, nil
+		}
+
+		if message := limitMessage(ctx, req.CardNumber, req.Amount); message != "" {
+			return &pb.CreateTransactionResponse{
+				IsCreated: false,
+				Message:   message,
+			}, nil
+		}
+
 		if cardRes.Balance >= req.Amount {
 
 			//Запуск горутины, отправляющей
-			go s.SendTransactionToQueue(ctx, req)
-
-			return &pb.CreateTransactionResponse{
+			return s.startTransaction(ctx, caller, req, &pb.CreateTransactionResponse{
 				IsCreated: true,
 				Message:   "Перевод успешно начат, вы получите уведомление, когда транзакция завершится",
-			}, nil
+			})
 		} else {
 			return &pb.CreateTransactionResponse{
 				IsCreated: false,
@@ -210,18 +358,74 @@ func (s *server) CreateTransaction(ctx context.Context, req *pb.CreateTransactio
 	}
 }
 
-func newServer(cardClient cardpb.CardServiceClient, rabbitConn *amqp.Connection, rdb *redis.Client, redisCl rds.CardServiceClient) *server {
+// ListTransactions возвращает проведённые переводы, пополнения и списания по карте
+func (s *server) ListTransactions(ctx context.Context, req *pb.ListTransactionsRequest) (*pb.ListTransactionsResponse, error) {
+	caller, err := authint.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if cardnumber.ValidateFormat(req.CardNumber) != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid card number")
+	}
+
+	// Чужая карта выглядит так же, как несуществующая
+	cardRes, err := s.cardClient.GetCard(ctx, &cardpb.GetCardRequest{CardNumber: req.CardNumber})
+	if err != nil {
+		log.Printf("Ошибка при получении карты: %v", err)
+		return nil, status.Error(codes.Unavailable, "card service is unavailable")
+	}
+	if cardRes.CardNumber == "" || !caller.CanViewUser(cardRes.UserId) {
+		return nil, status.Error(codes.NotFound, "card not found")
+	}
+
+	rows, err := usfl.DB.QueryContext(ctx, "SELECT card_number, recipient_card_number, amount, currency, COALESCE(target_amount, amount), COALESCE(target_currency, currency), COALESCE(rate, 1), kind, COALESCE(external_ref, '') FROM fintrans_successful_transactions_postgres WHERE card_number = $1 OR recipient_card_number = $1",
+		req.CardNumber)
+	if err != nil {
+		log.Printf("Ошибка при получении переводов: %v", err)
+		return nil, status.Error(codes.Internal, "could not load transactions")
+	}
+	defer rows.Close()
+
+	resp := &pb.ListTransactionsResponse{}
+	for rows.Next() {
+		var transaction pb.Transaction
+		if err := rows.Scan(&transaction.CardNumber, &transaction.RecipientCardNumber, &transaction.Amount, &transaction.Currency,
+			&transaction.TargetAmount, &transaction.TargetCurrency, &transaction.Rate, &transaction.Kind, &transaction.ExternalReferenceId); err != nil {
+			log.Printf("Ошибка при чтении перевода: %v", err)
+			return nil, status.Error(codes.Internal, "could not load transactions")
+		}
+		resp.Transactions = append(resp.Transactions, &transaction)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("Ошибка при получении переводов: %v", err)
+		return nil, status.Error(codes.Internal, "could not load transactions")
+	}
+	return resp, nil
+}
+
+func newServer(cardClient cardpb.CardServiceClient, rabbitConn *amqp.Connection, rdb *redis.Client, redisCl rds.CardServiceClient, authClient authpb.AuthServiceClient, rates fx.RateProvider) *server {
 	return &server{
 		cardClient:  cardClient,
 		rabbitConn:  rabbitConn,
 		redisServer: rdb,
 		redisClient: redisCl,
+		authClient:  authClient,
+		confirm:     loadConfirmationConfig(),
+		rates:       rates,
+		fx:          loadFXConfig(),
 	}
 }
 
 func startGRPCServer() {
 	// Настройка подключения к gRPC серверу CardService
-	conn, err := grpc.Dial("localhost:50051", grpc.WithInsecure(), grpc.WithBlock())
+	// Сервис карт вызывается от имени сервиса: владельца карты проверяем сами в CreateTransaction
+	serviceToken := os.Getenv(authint.ServiceTokenEnv)
+	if serviceToken == "" {
+		log.Printf("Не задан %s, CardService будет отклонять запросы", authint.ServiceTokenEnv)
+	}
+	conn, err := grpc.Dial("localhost:50051", grpc.WithInsecure(), grpc.WithBlock(),
+		grpc.WithPerRPCCredentials(authint.BearerToken(serviceToken)))
 	if err != nil {
 		log.Fatalf("не удалось подключиться к CardService: %v", err)
 	}
@@ -258,6 +462,20 @@ func startGRPCServer() {
 	} else {
 		fmt.Println("Successfully connected to the database!")
 	}
+	if err := pending.EnsureSchema(); err != nil {
+		log.Printf("Крупные переводы нельзя будет подтвердить: %v", err)
+	} else {
+		go pending.RunExpiry(time.Minute)
+	}
+	if err := history.EnsureSchema(); err != nil {
+		log.Printf("Не удалось обновить таблицу переводов: %v", err)
+	}
+	if err := limits.EnsureSchema(); err != nil {
+		log.Printf("Лимиты карт не будут проверяться: %v", err)
+	}
+	if err := fx.EnsureSchema(); err != nil {
+		log.Printf("Переводы с конвертацией валют будут недоступны: %v", err)
+	}
 
 	// Запускаем параллельное подключение к gRPC сервису RedisCacheServer
 	go func() {
@@ -284,9 +502,31 @@ func startGRPCServer() {
 		log.Fatalf("Failed to listen: %v", err)
 	}
 
-	grpcServer := grpc.NewServer()
-	pb.RegisterTransactionServiceServer(grpcServer, newServer(cardClient, rabbitConn, rdb, nil))
+	// Токены пользователей проверяются по публичным ключам AuthService,
+	// коды подтверждения переводов запрашиваются у него же от имени сервиса
+	authConn, err := grpc.Dial("localhost:50054", grpc.WithInsecure(),
+		grpc.WithPerRPCCredentials(authint.BearerToken(serviceToken)))
+	if err != nil {
+		log.Fatalf("не удалось подключиться к AuthService: %v", err)
+	}
+	defer authConn.Close()
+	authClient := authpb.NewAuthServiceClient(authConn)
+	validator := authint.ValidatorFromEnv(authClient)
+
+	rates, err := fx.ProviderFromEnv()
+	if err != nil {
+		log.Fatalf("не удалось загрузить курсы валют: %v", err)
+	}
+
+	grpcServer := grpc.NewServer(
+		grpc.UnaryInterceptor(authint.UnaryServerInterceptor(validator, transactionsPolicy)),
+		grpc.StreamInterceptor(authint.StreamServerInterceptor(validator, transactionsPolicy)),
+	)
+	pb.RegisterTransactionServiceServer(grpcServer, newServer(cardClient, rabbitConn, rdb, nil, authClient, rates))
 	reflection.Register(grpcServer)
+	if err := transactionsPolicy.Check(grpcServer); err != nil {
+		log.Fatalf("%v", err)
+	}
 
 	log.Println("Transactions gRPC server is running on port: 50052")
 	if err := grpcServer.Serve(lis); err != nil {
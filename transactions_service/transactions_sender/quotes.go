@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	authint "fin-trans/auth_interceptor_package"
+	usfl "fin-trans/database_methods_package"
+	models "fin-trans/models_package"
+	pb "fin-trans/proto/proto_generated/transactions_sender"
+	"fin-trans/transactions_service/fx"
+)
+
+func quoteToProto(q *models.FxQuote, amount float64) *pb.QuoteResponse {
+	resp := &pb.QuoteResponse{
+		QuoteId:      q.ID,
+		FromCurrency: q.FromCurrency,
+		ToCurrency:   q.ToCurrency,
+		Rate:         q.Rate,
+		ExpiresAt:    q.ExpiresAt.UTC().Format(time.RFC3339),
+		Used:         q.UsedAt != nil,
+	}
+	if amount > 0 {
+		resp.Amount = amount
+		resp.ConvertedAmount = fx.Convert(amount, q.Rate)
+	}
+	return resp
+}
+
+// CreateQuote фиксирует для вызывающего курс конвертации на FX_QUOTE_TTL.
+// quote_id передаётся в CreateTransaction, чтобы перевод прошёл по этому курсу.
+func (s *server) CreateQuote(ctx context.Context, req *pb.CreateQuoteRequest) (*pb.QuoteResponse, error) {
+	caller, err := authint.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !models.ValidCurrency(req.FromCurrency) || !models.ValidCurrency(req.ToCurrency) {
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported currency, expected one of %v", models.Currencies)
+	}
+	if req.FromCurrency == req.ToCurrency {
+		return nil, status.Error(codes.InvalidArgument, "currencies must differ")
+	}
+	if req.Amount < 0 {
+		return nil, status.Error(codes.InvalidArgument, "amount must not be negative")
+	}
+
+	quote, err := fx.NewQuote(ctx, s.rates, fx.Owner(caller.UserID, caller.ServiceAccountID), req.FromCurrency, req.ToCurrency, s.fx.QuoteTTL)
+	if errors.Is(err, fx.ErrUnsupportedPair) {
+		return nil, status.Error(codes.FailedPrecondition, "no exchange rate for this currency pair")
+	}
+	if err != nil {
+		log.Printf("Ошибка при создании котировки %s/%s: %v", req.FromCurrency, req.ToCurrency, err)
+		return nil, status.Error(codes.Internal, "could not create quote")
+	}
+	return quoteToProto(quote, req.Amount), nil
+}
+
+// GetQuote возвращает котировку вызывающего
+func (s *server) GetQuote(ctx context.Context, req *pb.GetQuoteRequest) (*pb.QuoteResponse, error) {
+	caller, err := authint.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Чужая котировка выглядит так же, как несуществующая
+	quote, err := fx.Load(ctx, usfl.DB, req.QuoteId)
+	if errors.Is(err, fx.ErrQuoteNotFound) || (err == nil && quote.Owner != fx.Owner(caller.UserID, caller.ServiceAccountID)) {
+		return nil, status.Error(codes.NotFound, "quote not found")
+	}
+	if err != nil {
+		log.Printf("Ошибка при получении котировки %s: %v", req.QuoteId, err)
+		return nil, status.Error(codes.Internal, "could not load quote")
+	}
+	return quoteToProto(quote, req.Amount), nil
+}
+
+// conversionQuote - котировка, по которой пройдёт перевод req на карту в валюте to:
+// переданная клиентом или зафиксированная сейчас по текущему курсу, если клиент
+// только попросил конвертацию. Котировка сразу отмечается использованной.
+// Вторым значением возвращается сообщение для клиента, если перевод невозможен.
+func (s *server) conversionQuote(ctx context.Context, caller *authint.Identity, req *pb.CreateTransactionRequest, to string) (*models.FxQuote, string, error) {
+	owner := fx.Owner(caller.UserID, caller.ServiceAccountID)
+	id := req.QuoteId
+	if id == "" {
+		quote, err := fx.NewQuote(ctx, s.rates, owner, req.Currency, to, s.fx.QuoteTTL)
+		if errors.Is(err, fx.ErrUnsupportedPair) {
+			return nil, fmt.Sprintf("Перевод из %s в %s недоступен", req.Currency, to), nil
+		}
+		if err != nil {
+			log.Printf("Ошибка при создании котировки %s/%s: %v", req.Currency, to, err)
+			return nil, "", status.Error(codes.Internal, "could not create quote")
+		}
+		id = quote.ID
+	} else {
+		quote, err := fx.Load(ctx, usfl.DB, id)
+		if err != nil && !errors.Is(err, fx.ErrQuoteNotFound) {
+			log.Printf("Ошибка при получении котировки %s: %v", id, err)
+			return nil, "", status.Error(codes.Internal, "could not load quote")
+		}
+		if err == nil && (quote.FromCurrency != req.Currency || quote.ToCurrency != to) {
+			return nil, fmt.Sprintf("Котировка выписана для пары %s/%s, а перевод из %s в %s", quote.FromCurrency, quote.ToCurrency, req.Currency, to), nil
+		}
+	}
+
+	quote, err := fx.Claim(ctx, usfl.DB, id, owner)
+	if errors.Is(err, fx.ErrQuoteNotFound) {
+		return nil, "Котировка не найдена, истекла или уже использована, запросите новую", nil
+	}
+	if err != nil {
+		log.Printf("Ошибка при использовании котировки %s: %v", id, err)
+		return nil, "", status.Error(codes.Internal, "could not use quote")
+	}
+	return quote, "", nil
+}